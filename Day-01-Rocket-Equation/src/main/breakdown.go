@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+)
+
+// ModuleFuel splits the fuel of a single module into the naive base fuel and the fuel needed to lift that fuel.
+type ModuleFuel struct {
+	mass, baseFuel, fuelForFuel, totalFuel int
+}
+
+func computeFuelBreakdown(masses []int) []ModuleFuel {
+	breakdown := make([]ModuleFuel, len(masses))
+	for i, mass := range masses {
+		baseFuel := computeBaseFuelMassFromModuleMass(mass)
+		totalFuel := computeFuelMassFromModuleMass(mass)
+		breakdown[i] = ModuleFuel{mass, baseFuel, totalFuel - baseFuel, totalFuel}
+	}
+	return breakdown
+}
+
+func sumFuelBreakdown(breakdown []ModuleFuel) ModuleFuel {
+	var sum ModuleFuel
+	for _, module := range breakdown {
+		sum.mass += module.mass
+		sum.baseFuel += module.baseFuel
+		sum.fuelForFuel += module.fuelForFuel
+		sum.totalFuel += module.totalFuel
+	}
+	return sum
+}
+
+func formatBreakdownTable(breakdown []ModuleFuel) string {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "module\tmass\tbase fuel\tfuel for fuel\ttotal fuel\t")
+	for i, module := range breakdown {
+		fmt.Fprintf(writer, "%d\t%d\t%d\t%d\t%d\t\n", i+1, module.mass, module.baseFuel, module.fuelForFuel,
+			module.totalFuel)
+	}
+	sum := sumFuelBreakdown(breakdown)
+	fmt.Fprintf(writer, "sum\t%d\t%d\t%d\t%d\t\n", sum.mass, sum.baseFuel, sum.fuelForFuel, sum.totalFuel)
+	writer.Flush()
+	return buffer.String()
+}
+
+func formatBreakdownCSV(breakdown []ModuleFuel) string {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	_ = writer.Write([]string{"module", "mass", "base_fuel", "fuel_for_fuel", "total_fuel"})
+	for i, module := range breakdown {
+		_ = writer.Write([]string{strconv.Itoa(i + 1), strconv.Itoa(module.mass), strconv.Itoa(module.baseFuel),
+			strconv.Itoa(module.fuelForFuel), strconv.Itoa(module.totalFuel)})
+	}
+	writer.Flush()
+	return buffer.String()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldComputeFuelBreakdown(t *testing.T) {
+	// given
+	masses := []int{14, 1969}
+
+	// when
+	breakdown := computeFuelBreakdown(masses)
+
+	// then
+	assert.Equal(t, []ModuleFuel{{14, 2, 0, 2}, {1969, 654, 312, 966}}, breakdown)
+	assert.Equal(t, ModuleFuel{1983, 656, 312, 968}, sumFuelBreakdown(breakdown))
+}
+
+func TestShouldFormatFuelBreakdownAsTable(t *testing.T) {
+	// given
+	breakdown := []ModuleFuel{{14, 2, 0, 2}, {1969, 654, 312, 966}}
+
+	// when
+	table := formatBreakdownTable(breakdown)
+
+	// then
+	assert.Equal(t, ""+
+		"  module  mass  base fuel  fuel for fuel  total fuel\n"+
+		"       1    14          2              0           2\n"+
+		"       2  1969        654            312         966\n"+
+		"     sum  1983        656            312         968\n", table)
+}
+
+func TestShouldFormatFuelBreakdownAsCSV(t *testing.T) {
+	// given
+	breakdown := []ModuleFuel{{14, 2, 0, 2}, {1969, 654, 312, 966}}
+
+	// when
+	result := formatBreakdownCSV(breakdown)
+
+	// then
+	assert.Equal(t, "module,mass,base_fuel,fuel_for_fuel,total_fuel\n1,14,2,0,2\n2,1969,654,312,966\n", result)
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// runCommand dispatches the tools available next to the puzzle solution, e.g. `go run ./src/main breakdown`.
+func runCommand(name string, args []string) error {
+	switch name {
+	case "breakdown":
+		return breakdownCommand(args)
+	case "payload":
+		return payloadCommand(args)
+	case "stages":
+		return stagesCommand(args)
+	case "stats":
+		return statsCommand(args)
+	case "fuel":
+		return fuelCommand(args)
+	case "trace":
+		return traceCommand(args)
+	}
+	return fmt.Errorf("unknown command %q", name)
+}
+
+// breakdownCommand prints base fuel, fuel-for-fuel and total fuel of every module as a table or CSV.
+func breakdownCommand(args []string) error {
+	flags := flag.NewFlagSet("breakdown", flag.ContinueOnError)
+	asCSV := flags.Bool("csv", false, "print CSV instead of a table")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	masses, err := loadMasses(flags.Args())
+	if err != nil {
+		return err
+	}
+	breakdown := computeFuelBreakdown(masses)
+	if *asCSV {
+		fmt.Print(formatBreakdownCSV(breakdown))
+	} else {
+		fmt.Print(formatBreakdownTable(breakdown))
+	}
+	return nil
+}
+
+// loadMasses reads the manifest given as the only argument, or the puzzle input when there is none. The format is
+// detected and masses are read from the `mass` column.
+func loadMasses(args []string) ([]int, error) {
+	manifest, err := loadManifestArgument(args, AutoFormat, defaultMassColumn)
+	if err != nil {
+		return nil, err
+	}
+	return manifest.masses(), nil
+}
+
+func loadManifestArgument(args []string, format ManifestFormat, column string) (Manifest, error) {
+	manifestPath := ""
+	switch len(args) {
+	case 0:
+		pwd, _ := os.Getwd()
+		manifestPath = pwd + path
+	case 1:
+		manifestPath = args[0]
+	default:
+		return Manifest{}, fmt.Errorf("expected at most one manifest file, found %d", len(args))
+	}
+	return loadManifestFile(manifestPath, format, column)
+}
+
+// payloadCommand prints the heaviest module a fuel budget can lift, e.g. `payload 966`. With module weights,
+// e.g. `payload 5000 1 1 2`, the budget is shared between the modules in proportion to their weights.
+func payloadCommand(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: payload <budget> [weight...]")
+	}
+	values := make([]int, len(args))
+	for i, arg := range args {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("cannot parse %v into number", arg)
+		}
+		values[i] = value
+	}
+	if len(values) == 1 {
+		maxMass, err := computeMaxModuleMass(values[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println(fmt.Sprintf("Max module mass >> %d (fuel %d)", maxMass, computeFuelMassFromModuleMass(maxMass)))
+		return nil
+	}
+	payloads, err := distributeFuelBudget(values[0], values[1:])
+	if err != nil {
+		return err
+	}
+	for i, payload := range payloads {
+		fmt.Println(fmt.Sprintf("Module %d >> fuel share %d, max mass %d, fuel used %d", i+1, payload.fuelShare,
+			payload.maxMass, payload.fuelUsed))
+	}
+	return nil
+}
+
+// stagesCommand prints the fuel of every stage of a rocket described in JSON, `src/data/rocket.json` by default.
+func stagesCommand(args []string) error {
+	rocketPath := ""
+	switch len(args) {
+	case 0:
+		pwd, _ := os.Getwd()
+		rocketPath = pwd + rocketExamplePath
+	case 1:
+		rocketPath = args[0]
+	default:
+		return errors.New("usage: stages [rocket.json]")
+	}
+	input, err := getInput(rocketPath)
+	if err != nil {
+		return err
+	}
+	rocket, err := parseRocket(input)
+	if err != nil {
+		return fmt.Errorf("%v: %v", rocketPath, err)
+	}
+	fmt.Print(formatStageFuel(computeStageFuel(rocket)))
+	return nil
+}
+
+// statsCommand prints mass and fuel statistics of a manifest and the modules dominating the total fuel.
+func statsCommand(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of text")
+	bins := flags.Int("bins", 10, "number of fuel histogram bins")
+	top := flags.Int("top", 5, "number of modules with the most fuel to list")
+	coverage := flags.Float64("coverage", 0.8, "share of the total fuel the smallest module set must cover")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	masses, err := loadMasses(flags.Args())
+	if err != nil {
+		return err
+	}
+	stats, err := computeFuelStats(masses, *bins, *top, *coverage)
+	if err != nil {
+		return err
+	}
+	if !*asJSON {
+		fmt.Print(formatFuelStatsText(stats))
+		return nil
+	}
+	output, err := formatFuelStatsJSON(stats)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
+
+// fuelCommand writes the manifest back in its own format, CSV, JSON, NDJSON or bare lines, with `fuel` and
+// `total_fuel` added to every module.
+func fuelCommand(args []string) error {
+	flags := flag.NewFlagSet("fuel", flag.ContinueOnError)
+	formatName := flags.String("format", string(AutoFormat), "manifest format: auto, lines, csv, json or ndjson")
+	column := flags.String("column", defaultMassColumn, "CSV column or JSON field holding the module mass")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	format, err := parseManifestFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	manifest, err := loadManifestArgument(flags.Args(), format, *column)
+	if err != nil {
+		return err
+	}
+	return writeFuelManifest(os.Stdout, manifest)
+}
+
+// traceCommand prints the fuel-for-fuel chain of the masses given as arguments, e.g. `trace 1969`, or of every
+// module of a manifest.
+func traceCommand(args []string) error {
+	flags := flag.NewFlagSet("trace", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "print JSON instead of text")
+	manifestPath := flags.String("manifest", "", "trace every module of the manifest, the puzzle input by default")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	var masses []int
+	if flags.NArg() > 0 {
+		for _, arg := range flags.Args() {
+			mass, err := strconv.Atoi(arg)
+			if err != nil {
+				return fmt.Errorf("cannot parse %v into number", arg)
+			}
+			masses = append(masses, mass)
+		}
+	} else {
+		var manifestArgs []string
+		if *manifestPath != "" {
+			manifestArgs = append(manifestArgs, *manifestPath)
+		}
+		var err error
+		if masses, err = loadMasses(manifestArgs); err != nil {
+			return err
+		}
+	}
+	traces := traceFuelForManifest(masses)
+	if !*asJSON {
+		for _, trace := range traces {
+			fmt.Println(trace)
+		}
+		return nil
+	}
+	output, err := formatFuelTracesJSON(traces)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+var ErrFuelOverflow = errors.New("fuel sum overflows int")
+
+// ShardResult is the part of a concurrent fuel sum computed by a single worker.
+type ShardResult struct {
+	modules, fuel int
+}
+
+type FuelSumResult struct {
+	modules, totalFuel int
+	shards             []ShardResult
+}
+
+// computeFuelSumConcurrently splits the masses into one contiguous shard per worker. Shards are merged in order, so
+// the result, including overflow errors, does not depend on scheduling and equals the sequential sum.
+func computeFuelSumConcurrently(masses []int, workers int) (FuelSumResult, error) {
+	if workers <= 0 {
+		return FuelSumResult{}, fmt.Errorf("number of workers must be positive, found %d", workers)
+	}
+	shardSize := (len(masses) + workers - 1) / workers
+	shards := make([]ShardResult, workers)
+	errs := make([]error, workers)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		start, end := worker*shardSize, (worker+1)*shardSize
+		if start > len(masses) {
+			start = len(masses)
+		}
+		if end > len(masses) {
+			end = len(masses)
+		}
+		waitGroup.Add(1)
+		go func(worker int, shard []int) {
+			defer waitGroup.Done()
+			shards[worker], errs[worker] = sumShard(shards[worker], shard)
+		}(worker, masses[start:end])
+	}
+	waitGroup.Wait()
+	return mergeShards(shards, errs)
+}
+
+// computeFuelSumFromReaderConcurrently streams the manifest in chunks. Chunk i always goes to worker i % workers,
+// which keeps per-shard counts deterministic without holding the manifest in memory.
+func computeFuelSumFromReaderConcurrently(reader io.Reader, workers, chunkSize int) (FuelSumResult, error) {
+	if workers <= 0 || chunkSize <= 0 {
+		return FuelSumResult{}, fmt.Errorf("workers and chunk size must be positive, found %d and %d", workers,
+			chunkSize)
+	}
+	chunks := make([]chan []int, workers)
+	shards := make([]ShardResult, workers)
+	errs := make([]error, workers)
+	var waitGroup sync.WaitGroup
+	for worker := range chunks {
+		chunks[worker] = make(chan []int, 1)
+		waitGroup.Add(1)
+		go func(worker int) {
+			defer waitGroup.Done()
+			for chunk := range chunks[worker] {
+				if errs[worker] == nil {
+					shards[worker], errs[worker] = sumShard(shards[worker], chunk)
+				}
+			}
+		}(worker)
+	}
+	chunk := make([]int, 0, chunkSize)
+	sent := 0
+	scanErr := scanModuleMasses(reader, func(mass int) {
+		chunk = append(chunk, mass)
+		if len(chunk) == chunkSize {
+			chunks[sent%workers] <- chunk
+			chunk = make([]int, 0, chunkSize)
+			sent++
+		}
+	})
+	if len(chunk) > 0 {
+		chunks[sent%workers] <- chunk
+	}
+	for worker := range chunks {
+		close(chunks[worker])
+	}
+	waitGroup.Wait()
+	if scanErr != nil {
+		return FuelSumResult{}, scanErr
+	}
+	return mergeShards(shards, errs)
+}
+
+func sumShard(shard ShardResult, masses []int) (ShardResult, error) {
+	for _, mass := range masses {
+		fuel, overflow := addFuel(shard.fuel, computeFuelMassFromModuleMass(mass))
+		if overflow {
+			return shard, ErrFuelOverflow
+		}
+		shard.fuel = fuel
+		shard.modules++
+	}
+	return shard, nil
+}
+
+func mergeShards(shards []ShardResult, errs []error) (FuelSumResult, error) {
+	result := FuelSumResult{shards: shards}
+	for i, shard := range shards {
+		if errs[i] != nil {
+			return FuelSumResult{}, fmt.Errorf("shard %d: %w", i, errs[i])
+		}
+		totalFuel, overflow := addFuel(result.totalFuel, shard.fuel)
+		if overflow {
+			return FuelSumResult{}, ErrFuelOverflow
+		}
+		result.totalFuel = totalFuel
+		result.modules += shard.modules
+	}
+	return result, nil
+}
+
+// addFuel adds two non-negative amounts of fuel and reports whether the sum overflowed.
+func addFuel(a, b int) (int, bool) {
+	sum := a + b
+	return sum, sum < a
+}
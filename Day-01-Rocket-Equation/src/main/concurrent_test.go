@@ -0,0 +1,92 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestShouldMatchSequentialSumConcurrently(t *testing.T) {
+	// given
+	random := rand.New(rand.NewSource(7))
+	masses := make([]int, 10001)
+	for i := range masses {
+		masses[i] = random.Intn(1000000)
+	}
+	expected := computeFuelSum(masses)
+
+	for _, workers := range []int{1, 2, 3, 8, 20000} {
+		// when
+		result, err := computeFuelSumConcurrently(masses, workers)
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, expected, result.totalFuel)
+		assert.Equal(t, len(masses), result.modules)
+		assert.Equal(t, workers, len(result.shards))
+	}
+}
+
+func TestShouldSplitMassesIntoContiguousShards(t *testing.T) {
+	// given
+	masses := []int{14, 1969, 100756, 12, 30}
+
+	// when
+	result, err := computeFuelSumConcurrently(masses, 2)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, FuelSumResult{5, 51324, []ShardResult{{3, 2 + 966 + 50346}, {2, 2 + 8}}}, result)
+}
+
+func TestShouldMatchSequentialSumFromReaderConcurrently(t *testing.T) {
+	// given
+	masses := make([]string, 1000)
+	expected := 0
+	for i := range masses {
+		masses[i] = strconv.Itoa(i * 97)
+		expected += computeFuelMassFromModuleMass(i * 97)
+	}
+	input := strings.Join(masses, "\n")
+
+	// when
+	result, err := computeFuelSumFromReaderConcurrently(strings.NewReader(input), 3, 100)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, expected, result.totalFuel)
+	assert.Equal(t, 1000, result.modules)
+	assert.Equal(t, []int{400, 300, 300}, []int{result.shards[0].modules, result.shards[1].modules,
+		result.shards[2].modules})
+}
+
+func TestShouldDetectFuelSumOverflow(t *testing.T) {
+	// given
+	masses := []int{math.MaxInt, math.MaxInt, math.MaxInt}
+	input := strconv.Itoa(math.MaxInt) + "\n" + strconv.Itoa(math.MaxInt) + "\n" + strconv.Itoa(math.MaxInt)
+
+	// when
+	_, err1 := computeFuelSumConcurrently(masses, 1)
+	_, err2 := computeFuelSumConcurrently(masses, 3)
+	_, err3 := computeFuelSumFromReaderConcurrently(strings.NewReader(input), 2, 1)
+
+	// then
+	assert.ErrorIs(t, err1, ErrFuelOverflow)
+	assert.ErrorIs(t, err2, ErrFuelOverflow)
+	assert.ErrorIs(t, err3, ErrFuelOverflow)
+}
+
+func TestShouldRejectInvalidConcurrencySettings(t *testing.T) {
+	// when
+	_, err1 := computeFuelSumConcurrently([]int{12}, 0)
+	_, err2 := computeFuelSumFromReaderConcurrently(strings.NewReader("12"), 2, 0)
+	_, err3 := computeFuelSumFromReaderConcurrently(strings.NewReader("12\nx"), 2, 10)
+
+	// then
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+	assert.Error(t, err3)
+}
@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type ManifestFormat string
+
+const (
+	AutoFormat   ManifestFormat = "auto"
+	LinesFormat  ManifestFormat = "lines"
+	CSVFormat    ManifestFormat = "csv"
+	JSONFormat   ManifestFormat = "json"
+	NDJSONFormat ManifestFormat = "ndjson"
+)
+
+const defaultMassColumn = "mass"
+
+// ModuleRecord is a module read from a manifest. The original CSV row or JSON object is kept, so ids and any
+// other columns make it to the output untouched.
+type ModuleRecord struct {
+	mass   int
+	row    []string
+	object map[string]interface{}
+}
+
+type Manifest struct {
+	format  ManifestFormat
+	header  []string
+	records []ModuleRecord
+}
+
+func parseManifestFormat(name string) (ManifestFormat, error) {
+	switch format := ManifestFormat(strings.ToLower(name)); format {
+	case AutoFormat, LinesFormat, CSVFormat, JSONFormat, NDJSONFormat:
+		return format, nil
+	}
+	return "", fmt.Errorf("unknown manifest format %q", name)
+}
+
+// manifestSniffSize is how much of a manifest is peeked at to detect its format.
+const manifestSniffSize = 64 << 10
+
+// detectManifestFormat looks at the file extension first and falls back to the first line that is neither blank nor
+// a `#` comment. A first line that is not a mass is taken as the header of a single column CSV. content only needs to
+// hold the beginning of the file.
+func detectManifestFormat(fileName string, content []byte) ManifestFormat {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		return CSVFormat
+	case ".json":
+		return JSONFormat
+	case ".ndjson", ".jsonl":
+		return NDJSONFormat
+	}
+	for _, line := range bytes.Split(content, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		switch {
+		case len(line) == 0 || line[0] == '#':
+			continue
+		case line[0] == '[':
+			return JSONFormat
+		case line[0] == '{':
+			return NDJSONFormat
+		case bytes.Contains(line, []byte(",")):
+			return CSVFormat
+		}
+		if mass, _, _ := bytes.Cut(line, []byte("#")); !isNumber(string(bytes.TrimSpace(mass))) {
+			return CSVFormat
+		}
+		return LinesFormat
+	}
+	return LinesFormat
+}
+
+// loadManifestFile only peeks at the beginning of the file to detect its format instead of reading it twice. The
+// records are still all kept in the returned Manifest.
+func loadManifestFile(path string, format ManifestFormat, column string) (Manifest, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer file.Close()
+	reader := bufio.NewReaderSize(file, manifestSniffSize)
+	if format == AutoFormat {
+		content, err := reader.Peek(manifestSniffSize)
+		if err != nil && err != io.EOF {
+			return Manifest{}, fmt.Errorf("%v: %v", path, err)
+		}
+		format = detectManifestFormat(path, content)
+	}
+	manifest, err := readManifest(reader, format, column)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("%v: %v", path, err)
+	}
+	return manifest, nil
+}
+
+func isNumber(text string) bool {
+	_, err := strconv.Atoi(text)
+	return err == nil
+}
+
+func readManifest(reader io.Reader, format ManifestFormat, column string) (Manifest, error) {
+	switch format {
+	case LinesFormat:
+		masses, err := parseModuleMasses(reader)
+		records := make([]ModuleRecord, len(masses))
+		for i, mass := range masses {
+			records[i] = ModuleRecord{mass: mass}
+		}
+		return Manifest{format: format, records: records}, err
+	case CSVFormat:
+		return readCSVManifest(reader, column)
+	case JSONFormat:
+		return readJSONManifest(reader, column)
+	case NDJSONFormat:
+		return readNDJSONManifest(reader, column)
+	}
+	return Manifest{}, fmt.Errorf("unsupported manifest format %q", format)
+}
+
+func readCSVManifest(reader io.Reader, column string) (Manifest, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	header, err := csvReader.Read()
+	if err != nil {
+		return Manifest{}, fmt.Errorf("cannot read CSV header: %v", err)
+	}
+	massIndex := -1
+	for i, name := range header {
+		if strings.TrimSpace(name) == column {
+			massIndex = i
+		}
+	}
+	if massIndex < 0 {
+		return Manifest{}, fmt.Errorf("column %q not found in CSV header %v", column, header)
+	}
+	manifest := Manifest{format: CSVFormat, header: header}
+	var manifestErrors ManifestErrors
+	for {
+		row, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Manifest{}, err
+		}
+		line, _ := csvReader.FieldPos(massIndex)
+		mass, massErr := parseMass(row[massIndex])
+		if massErr != "" {
+			manifestErrors = append(manifestErrors, LineError{line, row[massIndex], massErr})
+			continue
+		}
+		manifest.records = append(manifest.records, ModuleRecord{mass: mass, row: row})
+	}
+	if len(manifestErrors) > 0 {
+		return Manifest{}, manifestErrors
+	}
+	return manifest, nil
+}
+
+func readJSONManifest(reader io.Reader, column string) (Manifest, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	var objects []map[string]interface{}
+	if err := decoder.Decode(&objects); err != nil {
+		return Manifest{}, fmt.Errorf("expected a JSON array of objects: %v", err)
+	}
+	manifest := Manifest{format: JSONFormat}
+	var manifestErrors ManifestErrors
+	for i, object := range objects {
+		record, lineError := newObjectRecord(object, column, i+1)
+		if lineError != nil {
+			manifestErrors = append(manifestErrors, *lineError)
+			continue
+		}
+		manifest.records = append(manifest.records, record)
+	}
+	if len(manifestErrors) > 0 {
+		return Manifest{}, manifestErrors
+	}
+	return manifest, nil
+}
+
+func readNDJSONManifest(reader io.Reader, column string) (Manifest, error) {
+	scanner := bufio.NewScanner(reader)
+	manifest := Manifest{format: NDJSONFormat}
+	var manifestErrors ManifestErrors
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			manifestErrors = append(manifestErrors, LineError{line, text, "not a JSON object"})
+			continue
+		}
+		record, lineError := newObjectRecord(object, column, line)
+		if lineError != nil {
+			manifestErrors = append(manifestErrors, *lineError)
+			continue
+		}
+		manifest.records = append(manifest.records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return Manifest{}, err
+	}
+	if len(manifestErrors) > 0 {
+		return Manifest{}, manifestErrors
+	}
+	return manifest, nil
+}
+
+// newObjectRecord reads the mass of a JSON object, given either as a number or a numeric string. For JSON arrays
+// line is the position of the object in the array.
+func newObjectRecord(object map[string]interface{}, column string, line int) (ModuleRecord, *LineError) {
+	value, found := object[column]
+	if !found {
+		return ModuleRecord{}, &LineError{line, fmt.Sprint(object), fmt.Sprintf("missing %q field", column)}
+	}
+	mass, massErr := parseMass(fmt.Sprint(value))
+	if massErr != "" {
+		return ModuleRecord{}, &LineError{line, fmt.Sprint(value), massErr}
+	}
+	return ModuleRecord{mass: mass, object: object}, nil
+}
+
+// parseMass returns the mass, or a description of why it is not a valid one.
+func parseMass(text string) (int, string) {
+	mass, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return 0, "not a number"
+	}
+	if mass < 0 {
+		return 0, "negative mass"
+	}
+	return mass, ""
+}
+
+func (manifest Manifest) masses() []int {
+	masses := make([]int, len(manifest.records))
+	for i, record := range manifest.records {
+		masses[i] = record.mass
+	}
+	return masses
+}
+
+// writeFuelManifest writes the manifest back in its own format with the base fuel in a `fuel` column and the fuel
+// including fuel-for-fuel in a `total_fuel` column. Bare lines become `mass fuel total_fuel`.
+func writeFuelManifest(writer io.Writer, manifest Manifest) error {
+	switch manifest.format {
+	case CSVFormat:
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(append(append([]string{}, manifest.header...), "fuel", "total_fuel")); err != nil {
+			return err
+		}
+		for _, record := range manifest.records {
+			row := append(append([]string{}, record.row...), strconv.Itoa(computeBaseFuelMassFromModuleMass(record.mass)),
+				strconv.Itoa(computeFuelMassFromModuleMass(record.mass)))
+			if err := csvWriter.Write(row); err != nil {
+				return err
+			}
+		}
+		csvWriter.Flush()
+		return csvWriter.Error()
+	case JSONFormat:
+		objects := make([]map[string]interface{}, len(manifest.records))
+		for i, record := range manifest.records {
+			objects[i] = withFuelFields(record)
+		}
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(objects)
+	case NDJSONFormat:
+		encoder := json.NewEncoder(writer)
+		for _, record := range manifest.records {
+			if err := encoder.Encode(withFuelFields(record)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, record := range manifest.records {
+		if _, err := fmt.Fprintf(writer, "%d %d %d\n", record.mass, computeBaseFuelMassFromModuleMass(record.mass),
+			computeFuelMassFromModuleMass(record.mass)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func withFuelFields(record ModuleRecord) map[string]interface{} {
+	object := make(map[string]interface{}, len(record.object)+2)
+	for key, value := range record.object {
+		object[key] = value
+	}
+	object["fuel"] = computeBaseFuelMassFromModuleMass(record.mass)
+	object["total_fuel"] = computeFuelMassFromModuleMass(record.mass)
+	return object
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestShouldDetectManifestFormat(t *testing.T) {
+	// when
+	format1 := detectManifestFormat("modules.csv", []byte("12"))
+	format2 := detectManifestFormat("modules.jsonl", []byte("12"))
+	format3 := detectManifestFormat("input", []byte("  [{\"mass\": 12}]"))
+	format4 := detectManifestFormat("input", []byte("{\"mass\": 12}\n{\"mass\": 14}"))
+	format5 := detectManifestFormat("input", []byte("id,mass\na,12"))
+	format6 := detectManifestFormat("input", []byte("12\n14\n"))
+	format7 := detectManifestFormat("input", []byte("# engine modules, v2\n\n12\n14\n1969"))
+	format8 := detectManifestFormat("input", []byte("\n# exported, do not edit\nid,mass\na,12"))
+	format9 := detectManifestFormat("input", []byte("# modules\nmass\n12\n14"))
+	format10 := detectManifestFormat("input", []byte("12 # booster\n14"))
+
+	// then
+	assert.Equal(t, CSVFormat, format1)
+	assert.Equal(t, NDJSONFormat, format2)
+	assert.Equal(t, JSONFormat, format3)
+	assert.Equal(t, NDJSONFormat, format4)
+	assert.Equal(t, CSVFormat, format5)
+	assert.Equal(t, LinesFormat, format6)
+	assert.Equal(t, LinesFormat, format7)
+	assert.Equal(t, CSVFormat, format8)
+	assert.Equal(t, CSVFormat, format9)
+	assert.Equal(t, LinesFormat, format10)
+}
+
+func TestShouldLoadCommentedManifestOfBareLines(t *testing.T) {
+	// given
+	tmpfile, err := ioutil.TempFile("", "manifest")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if _, err := tmpfile.WriteString("# engine modules, v2\n12\n14\n1969"); err != nil {
+		log.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	// when
+	manifest, err := loadManifestFile(tmpfile.Name(), AutoFormat, defaultMassColumn)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, LinesFormat, manifest.format)
+	assert.Equal(t, []int{12, 14, 1969}, manifest.masses())
+}
+
+func TestShouldLoadSingleColumnCSVManifestWithHeader(t *testing.T) {
+	// given
+	tmpfile, err := ioutil.TempFile("", "manifest")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if _, err := tmpfile.WriteString("mass\n12\n14\n"); err != nil {
+		log.Fatal(err)
+	}
+	if err := tmpfile.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	// when
+	manifest, err := loadManifestFile(tmpfile.Name(), AutoFormat, defaultMassColumn)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, CSVFormat, manifest.format)
+	assert.Equal(t, []int{12, 14}, manifest.masses())
+}
+
+func TestShouldReadCSVManifestWithSelectedColumn(t *testing.T) {
+	// given
+	input := "id,name,weight\nm1,booster,1969\n# spare\nm2,\"cap, crew\",14\n"
+
+	// when
+	manifest, err := readManifest(strings.NewReader(input), CSVFormat, "weight")
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1969, 14}, manifest.masses())
+	assert.Equal(t, []string{"m2", "cap, crew", "14"}, manifest.records[1].row)
+}
+
+func TestShouldReportInvalidCSVMasses(t *testing.T) {
+	// given
+	input := "id,mass\nm1,abc\nm2,12\nm3,-4\n"
+
+	// when
+	_, err1 := readManifest(strings.NewReader(input), CSVFormat, "mass")
+	_, err2 := readManifest(strings.NewReader(input), CSVFormat, "weight")
+
+	// then
+	assert.Equal(t, ManifestErrors{{2, "abc", "not a number"}, {4, "-4", "negative mass"}}, err1)
+	assert.EqualError(t, err2, "column \"weight\" not found in CSV header [id mass]")
+}
+
+func TestShouldReadJSONAndNDJSONManifests(t *testing.T) {
+	// given
+	jsonInput := `[{"id": "m1", "mass": 1969}, {"id": "m2", "mass": "14"}]`
+	ndjsonInput := "{\"id\": 1, \"mass\": 1969}\n\n{\"id\": 2, \"mass\": 14}\n"
+
+	// when
+	manifest1, err1 := readManifest(strings.NewReader(jsonInput), JSONFormat, "mass")
+	manifest2, err2 := readManifest(strings.NewReader(ndjsonInput), NDJSONFormat, "mass")
+	_, err3 := readManifest(strings.NewReader("{\"id\": 1}\nnope\n"), NDJSONFormat, "mass")
+
+	// then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, []int{1969, 14}, manifest1.masses())
+	assert.Equal(t, []int{1969, 14}, manifest2.masses())
+	assert.Equal(t, 2, len(err3.(ManifestErrors)))
+}
+
+func TestShouldWriteFuelInManifestFormat(t *testing.T) {
+	// given
+	csvInput := "id,mass\nm1,1969\nm2,14\n"
+	jsonInput := `[{"id": "m1", "mass": 1969}]`
+	ndjsonInput := "{\"id\": 7, \"mass\": 14}\n"
+	linesInput := "1969\n14\n"
+
+	// when
+	csvOutput := writeManifestToString(t, csvInput, CSVFormat)
+	jsonOutput := writeManifestToString(t, jsonInput, JSONFormat)
+	ndjsonOutput := writeManifestToString(t, ndjsonInput, NDJSONFormat)
+	linesOutput := writeManifestToString(t, linesInput, LinesFormat)
+
+	// then
+	assert.Equal(t, "id,mass,fuel,total_fuel\nm1,1969,654,966\nm2,14,2,2\n", csvOutput)
+	assert.Equal(t, "[\n  {\n    \"fuel\": 654,\n    \"id\": \"m1\",\n    \"mass\": 1969,\n"+
+		"    \"total_fuel\": 966\n  }\n]\n", jsonOutput)
+	assert.Equal(t, "{\"fuel\":2,\"id\":7,\"mass\":14,\"total_fuel\":2}\n", ndjsonOutput)
+	assert.Equal(t, "1969 654 966\n14 2 2\n", linesOutput)
+}
+
+func TestShouldRejectUnknownManifestFormat(t *testing.T) {
+	// when
+	format, err1 := parseManifestFormat("CSV")
+	_, err2 := parseManifestFormat("yaml")
+
+	// then
+	assert.Nil(t, err1)
+	assert.Equal(t, CSVFormat, format)
+	assert.Error(t, err2)
+}
+
+func writeManifestToString(t *testing.T, input string, format ManifestFormat) string {
+	manifest, err := readManifest(strings.NewReader(input), format, defaultMassColumn)
+	assert.Nil(t, err)
+	var buffer bytes.Buffer
+	assert.Nil(t, writeFuelManifest(&buffer, manifest))
+	return buffer.String()
+}
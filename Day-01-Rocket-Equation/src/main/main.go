@@ -5,22 +5,27 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
-	"strconv"
 	"strings"
 )
 
 const path = "/src/data/input"
+const rocketExamplePath = "/src/data/rocket.json"
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	fmt.Println("--- Day 1: The Tyranny of the Rocket Equation ---")
 	pwd, _ := os.Getwd()
-	input, err := getInput(pwd + path)
+	masses, err := loadManifest(pwd + path)
 	if err != nil {
 		log.Fatal(err)
 	}
-	masses := getIntModulesMassesFromInput(input)
-	result := computeFuelSum(masses)
-	fmt.Println(fmt.Sprintf("Part 2 >> %d", result))
+	fmt.Println(fmt.Sprintf("Part 1 >> %d", computeBaseFuelSum(masses)))
+	fmt.Println(fmt.Sprintf("Part 2 >> %d", computeFuelSum(masses)))
 }
 
 func getInput(path string) (string, error) {
@@ -31,33 +36,40 @@ func getInput(path string) (string, error) {
 	return string(content), nil
 }
 
-func getIntModulesMassesFromInput(input string) []int {
-	massesSplit := strings.Split(input, "\n")
-	var massesInt []int
-	for _, mass := range massesSplit {
-		tmp, err := strconv.Atoi(mass)
-		if err != nil {
-			log.Fatal(fmt.Sprintf("Unexpected input string %v", mass))
-		}
-		massesInt = append(massesInt, tmp)
+func loadManifest(path string) ([]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
 	}
-	return massesInt
+	defer file.Close()
+	return parseModuleMasses(file)
+}
+
+func getIntModulesMassesFromInput(input string) ([]int, error) {
+	return parseModuleMasses(strings.NewReader(input))
+}
+
+// computeBaseFuelMassFromModuleMass ignores the mass of the fuel itself.
+func computeBaseFuelMassFromModuleMass(mass int) int {
+	return part1Model.computeFuel(mass)
 }
 
 func computeFuelMassFromModuleMass(mass int) int {
+	return part2Model.computeFuel(mass)
+}
+
+func computeFuelSum(masses []int) int {
 	result := 0
-	fuelMass := (mass / 3) - 2
-	if fuelMass > 0 {
-		result += fuelMass
-		result += computeFuelMassFromModuleMass(fuelMass)
+	for _, mass := range masses {
+		result += computeFuelMassFromModuleMass(mass)
 	}
 	return result
 }
 
-func computeFuelSum(masses []int) interface{} {
+func computeBaseFuelSum(masses []int) int {
 	result := 0
 	for _, mass := range masses {
-		result += computeFuelMassFromModuleMass(mass)
+		result += computeBaseFuelMassFromModuleMass(mass)
 	}
 	return result
 }
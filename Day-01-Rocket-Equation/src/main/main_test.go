@@ -44,9 +44,10 @@ func TestShouldSplitInputIntoMasses(t *testing.T) {
 	input := "1234\n9999\n1\n888"
 
 	// when
-	masses := getIntModulesMassesFromInput(input)
+	masses, err := getIntModulesMassesFromInput(input)
 
 	// then
+	assert.Nil(t, err)
 	assert.NotNil(t, masses)
 	assert.Equal(t, 4, len(masses))
 	assert.Equal(t, []int{1234, 9999, 1, 888}, masses)
@@ -82,3 +83,14 @@ func TestShouldComputeFuelSum(t *testing.T) {
 	// then
 	assert.Equal(t, 978, result)
 }
+
+func TestShouldComputeBaseFuelSum(t *testing.T) {
+	// given
+	input := []int{12, 14, 1969, 100756}
+
+	// when
+	result := computeBaseFuelSum(input)
+
+	// then
+	assert.Equal(t, 34241, result)
+}
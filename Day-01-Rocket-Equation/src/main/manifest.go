@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LineError is a manifest line that could not be read as a module mass.
+type LineError struct {
+	line int
+	text string
+	err  string
+}
+
+// ManifestErrors collects every bad line of a manifest, so all of them can be fixed at once.
+type ManifestErrors []LineError
+
+func (lineError LineError) Error() string {
+	return fmt.Sprintf("line %d: %s (%q)", lineError.line, lineError.err, lineError.text)
+}
+
+func (manifestErrors ManifestErrors) Error() string {
+	messages := make([]string, len(manifestErrors))
+	for i, lineError := range manifestErrors {
+		messages[i] = lineError.Error()
+	}
+	return fmt.Sprintf("%d invalid manifest lines:\n%s", len(manifestErrors), strings.Join(messages, "\n"))
+}
+
+// scanModuleMasses reads one module mass per line without loading the whole manifest. Blank lines and `#` comments
+// are skipped and CRLF line endings are accepted. visit is called for every valid mass, the bad lines are returned
+// together as ManifestErrors once the whole input has been read.
+func scanModuleMasses(reader io.Reader, visit func(mass int)) error {
+	scanner := bufio.NewScanner(reader)
+	var manifestErrors ManifestErrors
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		mass := text
+		if comment := strings.Index(mass, "#"); comment >= 0 {
+			mass = mass[:comment]
+		}
+		mass = strings.TrimSpace(mass)
+		if mass == "" {
+			continue
+		}
+		value, err := strconv.Atoi(mass)
+		if err != nil {
+			manifestErrors = append(manifestErrors, LineError{line, text, "not a number"})
+			continue
+		}
+		if value < 0 {
+			manifestErrors = append(manifestErrors, LineError{line, text, "negative mass"})
+			continue
+		}
+		visit(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(manifestErrors) > 0 {
+		return manifestErrors
+	}
+	return nil
+}
+
+func parseModuleMasses(reader io.Reader) ([]int, error) {
+	var masses []int
+	err := scanModuleMasses(reader, func(mass int) {
+		masses = append(masses, mass)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return masses, nil
+}
+
+// computeFuelSumFromReader streams the manifest through the model, so its size is not limited by memory.
+func computeFuelSumFromReader(reader io.Reader, model FuelModel) (int, error) {
+	result := 0
+	err := scanModuleMasses(reader, func(mass int) {
+		result += model.computeFuel(mass)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
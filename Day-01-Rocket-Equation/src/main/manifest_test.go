@@ -0,0 +1,68 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestShouldParseManifestWithCommentsBlankLinesAndCRLF(t *testing.T) {
+	// given
+	input := "# engine modules\r\n1234\r\n\r\n9999 # heavy one\r\n  1\n888\n"
+
+	// when
+	masses, err := parseModuleMasses(strings.NewReader(input))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1234, 9999, 1, 888}, masses)
+}
+
+func TestShouldCollectAllManifestErrorsWithLineNumbers(t *testing.T) {
+	// given
+	input := "12\nabc\n-5\n14\n1.5\n"
+
+	// when
+	masses, err := parseModuleMasses(strings.NewReader(input))
+
+	// then
+	assert.Nil(t, masses)
+	assert.Equal(t, ManifestErrors{
+		{2, "abc", "not a number"},
+		{3, "-5", "negative mass"},
+		{5, "1.5", "not a number"},
+	}, err)
+	assert.Equal(t, "3 invalid manifest lines:\n"+
+		"line 2: not a number (\"abc\")\n"+
+		"line 3: negative mass (\"-5\")\n"+
+		"line 5: not a number (\"1.5\")", err.Error())
+}
+
+func TestShouldAcceptTrailingNewline(t *testing.T) {
+	// given
+	input := "12\n14\n"
+
+	// when
+	masses, err := getIntModulesMassesFromInput(input)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []int{12, 14}, masses)
+}
+
+func TestShouldComputeFuelSumFromReader(t *testing.T) {
+	// given
+	input := "30\n20\n1969\n"
+
+	// when
+	result1, err1 := computeFuelSumFromReader(strings.NewReader(input), part2Model)
+	result2, err2 := computeFuelSumFromReader(strings.NewReader(input), part1Model)
+	_, err3 := computeFuelSumFromReader(strings.NewReader("30\nx\n"), part2Model)
+
+	// then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, 978, result1)
+	assert.Equal(t, 8+4+654, result2)
+	assert.Error(t, err3)
+}
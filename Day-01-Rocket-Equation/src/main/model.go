@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Rounding int
+
+const (
+	Floor Rounding = iota
+	Ceil
+	Nearest
+)
+
+// FuelModel describes an engine: the fuel burnt for a mass is the mass divided by divisor, rounded, minus offset.
+// With fuelForFuel the fuel itself needs fuel, and so on. Increments not greater than zero or smaller than
+// minimumBurn are not burnt, which also ends the fuel-for-fuel chain.
+type FuelModel struct {
+	divisor, offset int
+	rounding        Rounding
+	fuelForFuel     bool
+	minimumBurn     int
+}
+
+var part1Model = FuelModel{divisor: 3, offset: 2, rounding: Floor}
+var part2Model = FuelModel{divisor: 3, offset: 2, rounding: Floor, fuelForFuel: true}
+
+func (model FuelModel) validate() error {
+	if model.divisor <= 0 {
+		return fmt.Errorf("divisor must be positive, found %d", model.divisor)
+	}
+	if model.rounding < Floor || model.rounding > Nearest {
+		return fmt.Errorf("unknown rounding %d", model.rounding)
+	}
+	// burn(m) - m never grows with m, so fuel-for-fuel shrinks to zero exactly when a unit mass needs no fuel
+	if model.fuelForFuel && model.burn(1) >= 1 {
+		return errors.New("fuel-for-fuel never converges, a unit mass must need no fuel")
+	}
+	return nil
+}
+
+func (model FuelModel) computeFuel(mass int) int {
+	fuel, burnt := model.fuelIncrement(mass)
+	if !burnt {
+		return 0
+	}
+	if !model.fuelForFuel {
+		return fuel
+	}
+	return fuel + model.computeFuel(fuel)
+}
+
+// fuelIncrement returns the fuel burnt for the mass and whether it is burnt at all. Every int variant of computeFuel
+// goes through it, so the cutoffs live in a single place.
+func (model FuelModel) fuelIncrement(mass int) (int, bool) {
+	fuel := model.burn(mass)
+	return fuel, fuel > 0 && fuel >= model.minimumBurn
+}
+
+// walkFuel calls visit with every fuel increment of the mass in order, following fuel-for-fuel with a loop.
+func (model FuelModel) walkFuel(mass int, visit func(fuel int)) {
+	for fuel, burnt := model.fuelIncrement(mass); burnt; fuel, burnt = model.fuelIncrement(fuel) {
+		visit(fuel)
+		if !model.fuelForFuel {
+			break
+		}
+	}
+}
+
+// burn returns a single fuel increment for the mass, without fuel-for-fuel or cutoffs.
+func (model FuelModel) burn(mass int) int {
+	return model.rounding.divide(mass, model.divisor) - model.offset
+}
+
+func (model FuelModel) computeFuelSum(masses []int) int {
+	result := 0
+	for _, mass := range masses {
+		result += model.computeFuel(mass)
+	}
+	return result
+}
+
+func (rounding Rounding) divide(a, b int) int {
+	switch rounding {
+	case Ceil:
+		return -floorDivide(-a, b)
+	case Nearest:
+		return floorDivide(2*a+b, 2*b)
+	}
+	return floorDivide(a, b)
+}
+
+func floorDivide(a, b int) int {
+	quotient := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		quotient--
+	}
+	return quotient
+}
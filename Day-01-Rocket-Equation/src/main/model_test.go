@@ -0,0 +1,86 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldComputeFuelWithPuzzlePresets(t *testing.T) {
+	// when
+	part1Fuel1 := part1Model.computeFuel(1969)
+	part1Fuel2 := part1Model.computeFuel(100756)
+	part2Fuel1 := part2Model.computeFuel(1969)
+	part2Fuel2 := part2Model.computeFuel(100756)
+
+	// then
+	assert.Equal(t, 654, part1Fuel1)
+	assert.Equal(t, 33583, part1Fuel2)
+	assert.Equal(t, 966, part2Fuel1)
+	assert.Equal(t, 50346, part2Fuel2)
+}
+
+func TestShouldRoundDivisionBasedOnMode(t *testing.T) {
+	// when
+	floor1 := Floor.divide(7, 3)
+	floor2 := Floor.divide(-7, 3)
+	ceil1 := Ceil.divide(7, 3)
+	ceil2 := Ceil.divide(-7, 3)
+	nearest1 := Nearest.divide(7, 3)
+	nearest2 := Nearest.divide(8, 3)
+	nearest3 := Nearest.divide(3, 2)
+
+	// then
+	assert.Equal(t, 2, floor1)
+	assert.Equal(t, -3, floor2)
+	assert.Equal(t, 3, ceil1)
+	assert.Equal(t, -2, ceil2)
+	assert.Equal(t, 2, nearest1)
+	assert.Equal(t, 3, nearest2)
+	assert.Equal(t, 2, nearest3)
+}
+
+func TestShouldComputeFuelWithCustomModel(t *testing.T) {
+	// given
+	ceilModel := FuelModel{divisor: 3, offset: 2, rounding: Ceil, fuelForFuel: true}
+	cutoffModel := FuelModel{divisor: 3, offset: 2, rounding: Floor, fuelForFuel: true, minimumBurn: 70}
+
+	// when
+	ceilFuel := ceilModel.computeFuel(1969)
+	cutoffFuel := cutoffModel.computeFuel(1969)
+
+	// then
+	assert.Equal(t, 655+217+71+22+6, ceilFuel)
+	assert.Equal(t, 654+216+70, cutoffFuel)
+}
+
+func TestShouldComputeFuelSumWithModel(t *testing.T) {
+	// given
+	masses := []int{12, 14, 1969, 100756}
+
+	// when
+	result1 := part1Model.computeFuelSum(masses)
+	result2 := part2Model.computeFuelSum(masses)
+
+	// then
+	assert.Equal(t, 2+2+654+33583, result1)
+	assert.Equal(t, 2+2+966+50346, result2)
+}
+
+func TestShouldValidateFuelModel(t *testing.T) {
+	// given
+	noDivisor := FuelModel{divisor: 0, offset: 2}
+	diverging := FuelModel{divisor: 2, offset: 0, rounding: Ceil, fuelForFuel: true}
+	noFuelForFuel := FuelModel{divisor: 2, offset: 0, rounding: Ceil}
+
+	// when
+	err1 := noDivisor.validate()
+	err2 := diverging.validate()
+	err3 := noFuelForFuel.validate()
+	err4 := part2Model.validate()
+
+	// then
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+	assert.Nil(t, err3)
+	assert.Nil(t, err4)
+}
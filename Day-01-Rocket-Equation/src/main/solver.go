@@ -0,0 +1,136 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const maxSolverMass = math.MaxInt / 4
+
+// ModulePayload is the part of a shared fuel budget given to a single module and the heaviest module it can lift.
+type ModulePayload struct {
+	fuelShare, maxMass, fuelUsed int
+}
+
+// computeMaxModuleMass returns the heaviest module whose total fuel, including fuel-for-fuel, fits within budget.
+func computeMaxModuleMass(budget int) (int, error) {
+	return part2Model.computeMaxMass(budget)
+}
+
+// computeMaxMass relies on computeFuel never decreasing when mass grows. A closed-form guess brackets the answer
+// and binary search finds the exact mass.
+func (model FuelModel) computeMaxMass(budget int) (int, error) {
+	if err := model.validate(); err != nil {
+		return 0, err
+	}
+	if budget < 0 {
+		return 0, fmt.Errorf("fuel budget cannot be negative, found %d", budget)
+	}
+	low, high, err := model.bracketMaxMass(budget)
+	if err != nil {
+		return 0, err
+	}
+	for high-low > 1 {
+		middle := low + (high-low)/2
+		if model.computeFuel(middle) <= budget {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+	return low, nil
+}
+
+// bracketMaxMass returns masses low and high with fuel(low) <= budget < fuel(high). It fails when even
+// maxSolverMass fits within the budget, as the true maximum is then out of reach.
+func (model FuelModel) bracketMaxMass(budget int) (int, int, error) {
+	guess := model.guessMaxMass(budget)
+	low, high := guess, guess
+	step := model.divisor + model.offset + 1
+	for low > 0 && model.computeFuel(low) > budget {
+		high = low
+		low -= step
+		step *= 2
+	}
+	if low < 0 {
+		low = 0
+	}
+	step = model.divisor + model.offset + 1
+	for high < maxSolverMass && model.computeFuel(high) <= budget {
+		low = high
+		high += step
+		step *= 2
+	}
+	if high >= maxSolverMass {
+		high = maxSolverMass
+		if model.computeFuel(high) <= budget {
+			return 0, 0, fmt.Errorf("fuel budget %d lifts more than the largest supported mass %d", budget,
+				maxSolverMass)
+		}
+	}
+	return low, high, nil
+}
+
+// guessMaxMass inverts the continuous rocket equation. Fuel-for-fuel adds a geometric series, so the total fuel of
+// mass m is roughly m / (divisor - 1).
+func (model FuelModel) guessMaxMass(budget int) int {
+	factor := model.divisor
+	if model.fuelForFuel && model.divisor > 1 {
+		factor = model.divisor - 1
+	}
+	// both products stay within maxSolverMass, so neither they nor their sum can overflow
+	if budget > maxSolverMass/factor || model.offset > maxSolverMass/model.divisor ||
+		model.offset < -maxSolverMass/model.divisor {
+		return maxSolverMass
+	}
+	guess := budget*factor + model.offset*model.divisor
+	if guess < 0 || guess > maxSolverMass {
+		return maxSolverMass
+	}
+	return guess
+}
+
+// distributeFuelBudget splits the budget between modules in proportion to their weights, using the largest
+// remainder method so the shares add up to the budget, and solves the heaviest mass of every module.
+func distributeFuelBudget(budget int, weights []int) ([]ModulePayload, error) {
+	if len(weights) == 0 {
+		return nil, errors.New("no modules to distribute the fuel budget to")
+	}
+	weightSum := 0
+	for _, weight := range weights {
+		if weight <= 0 {
+			return nil, fmt.Errorf("module weight must be positive, found %d", weight)
+		}
+		weightSum += weight
+	}
+	shares := make([]int, len(weights))
+	remainders := make([]int, len(weights))
+	distributed := 0
+	for i, weight := range weights {
+		shares[i] = budget / weightSum * weight
+		remainders[i] = budget % weightSum * weight
+		shares[i] += remainders[i] / weightSum
+		remainders[i] %= weightSum
+		distributed += shares[i]
+	}
+	for ; distributed < budget; distributed++ {
+		largest := 0
+		for i := range remainders {
+			if remainders[i] > remainders[largest] {
+				largest = i
+			}
+		}
+		shares[largest]++
+		remainders[largest] = -1
+	}
+	payloads := make([]ModulePayload, len(weights))
+	for i, share := range shares {
+		maxMass, err := computeMaxModuleMass(share)
+		if err != nil {
+			return nil, err
+		}
+		payloads[i] = ModulePayload{share, maxMass, computeFuelMassFromModuleMass(maxMass)}
+	}
+	return payloads, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestShouldComputeMaxModuleMassForBudget(t *testing.T) {
+	// when
+	mass1, err1 := computeMaxModuleMass(0)
+	mass2, err2 := computeMaxModuleMass(2)
+	mass3, err3 := computeMaxModuleMass(966)
+	_, err4 := computeMaxModuleMass(-1)
+
+	// then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Error(t, err4)
+	assert.Equal(t, 8, mass1)
+	assert.Equal(t, 14, mass2)
+	assert.Equal(t, 1970, mass3)
+}
+
+func TestShouldFindHeaviestMassWithinBudget(t *testing.T) {
+	// given
+	models := []FuelModel{part1Model, part2Model, {divisor: 5, offset: 1, rounding: Nearest, fuelForFuel: true}}
+	budgets := []int{1, 17, 654, 50346, 123456789}
+
+	for _, model := range models {
+		for _, budget := range budgets {
+			// when
+			mass, err := model.computeMaxMass(budget)
+
+			// then
+			assert.Nil(t, err)
+			assert.True(t, model.computeFuel(mass) <= budget)
+			assert.True(t, model.computeFuel(mass+1) > budget)
+		}
+	}
+}
+
+func TestShouldFindHeaviestMassForLargeBudget(t *testing.T) {
+	// when
+	mass, err := part2Model.computeMaxMass(1 << 59)
+
+	// then
+	assert.Nil(t, err)
+	assert.True(t, part2Model.computeFuel(mass) <= 1<<59)
+	assert.True(t, part2Model.computeFuel(mass+1) > 1<<59)
+}
+
+func TestShouldRejectBudgetLiftingMoreThanLargestSupportedMass(t *testing.T) {
+	// given
+	wideModel := FuelModel{divisor: 1 << 40, offset: 2, rounding: Floor}
+
+	// when
+	_, err1 := part1Model.computeMaxMass(math.MaxInt)
+	_, err2 := wideModel.computeMaxMass(1 << 30)
+
+	// then
+	assert.EqualError(t, err1, fmt.Sprintf("fuel budget %d lifts more than the largest supported mass %d",
+		math.MaxInt, maxSolverMass))
+	assert.EqualError(t, err2, fmt.Sprintf("fuel budget %d lifts more than the largest supported mass %d",
+		1<<30, maxSolverMass))
+}
+
+func TestShouldDistributeFuelBudgetByWeights(t *testing.T) {
+	// when
+	payloads, err1 := distributeFuelBudget(1000, []int{1, 1, 2})
+	_, err2 := distributeFuelBudget(1000, []int{1, 0})
+	_, err3 := distributeFuelBudget(1000, nil)
+
+	// then
+	assert.Nil(t, err1)
+	assert.Error(t, err2)
+	assert.Error(t, err3)
+	assert.Equal(t, 3, len(payloads))
+	assert.Equal(t, 250, payloads[0].fuelShare)
+	assert.Equal(t, 250, payloads[1].fuelShare)
+	assert.Equal(t, 500, payloads[2].fuelShare)
+	for _, payload := range payloads {
+		assert.True(t, payload.fuelUsed <= payload.fuelShare)
+		assert.Equal(t, computeFuelMassFromModuleMass(payload.maxMass), payload.fuelUsed)
+	}
+}
+
+func TestShouldDistributeWholeBudgetWithLargestRemainders(t *testing.T) {
+	// when
+	payloads, err := distributeFuelBudget(10, []int{1, 1, 1})
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, 4, payloads[0].fuelShare)
+	assert.Equal(t, 3, payloads[1].fuelShare)
+	assert.Equal(t, 3, payloads[2].fuelShare)
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Stage is a part of the rocket holding its own modules and the upper stages it lifts.
+type Stage struct {
+	Name    string  `json:"name"`
+	Modules []int   `json:"modules"`
+	Stages  []Stage `json:"stages"`
+}
+
+// StageFuel is the fuel of a stage. Upper stages, together with their fuel, are payload of the stage below, so
+// totalMass is what the stage weighs for the stage carrying it.
+type StageFuel struct {
+	name                                  string
+	dryMass, payloadMass, fuel, totalMass int
+	stages                                []StageFuel
+}
+
+func parseRocket(input string) (Stage, error) {
+	var rocket Stage
+	if err := json.Unmarshal([]byte(input), &rocket); err != nil {
+		return Stage{}, err
+	}
+	if err := validateStage(rocket, rocket.Name); err != nil {
+		return Stage{}, err
+	}
+	return rocket, nil
+}
+
+func validateStage(stage Stage, stagePath string) error {
+	for _, mass := range stage.Modules {
+		if mass < 0 {
+			return fmt.Errorf("stage %q: negative module mass %d", stagePath, mass)
+		}
+	}
+	for _, upperStage := range stage.Stages {
+		if err := validateStage(upperStage, stagePath+"/"+upperStage.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// computeStageFuel burns fuel for every module of the stage, like computeFuelSum, and for every upper stage taken
+// as a single payload of its total mass.
+func computeStageFuel(stage Stage) StageFuel {
+	result := StageFuel{name: stage.Name}
+	for _, mass := range stage.Modules {
+		result.dryMass += mass
+		result.fuel += computeFuelMassFromModuleMass(mass)
+	}
+	for _, upperStage := range stage.Stages {
+		upperStageFuel := computeStageFuel(upperStage)
+		result.payloadMass += upperStageFuel.totalMass
+		result.fuel += computeFuelMassFromModuleMass(upperStageFuel.totalMass)
+		result.stages = append(result.stages, upperStageFuel)
+	}
+	result.totalMass = result.dryMass + result.payloadMass + result.fuel
+	return result
+}
+
+func (stageFuel StageFuel) totalFuel() int {
+	result := stageFuel.fuel
+	for _, upperStage := range stageFuel.stages {
+		result += upperStage.totalFuel()
+	}
+	return result
+}
+
+func formatStageFuel(stageFuel StageFuel) string {
+	var builder strings.Builder
+	writeStageFuel(&builder, stageFuel, 0)
+	builder.WriteString(fmt.Sprintf("Total fuel >> %d\n", stageFuel.totalFuel()))
+	return builder.String()
+}
+
+func writeStageFuel(builder *strings.Builder, stageFuel StageFuel, depth int) {
+	builder.WriteString(fmt.Sprintf("%s%s: dry mass %d, payload %d, fuel %d, total mass %d\n",
+		strings.Repeat("  ", depth), stageFuel.name, stageFuel.dryMass, stageFuel.payloadMass, stageFuel.fuel,
+		stageFuel.totalMass))
+	for _, upperStage := range stageFuel.stages {
+		writeStageFuel(builder, upperStage, depth+1)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldMatchFlatFuelSumForSingleStage(t *testing.T) {
+	// given
+	rocket := Stage{Name: "single", Modules: []int{30, 20, 1969}}
+
+	// when
+	result := computeStageFuel(rocket)
+
+	// then
+	assert.Equal(t, computeFuelSum(rocket.Modules), result.totalFuel())
+	assert.Equal(t, StageFuel{"single", 2019, 0, 978, 2997, nil}, result)
+}
+
+func TestShouldCountUpperStageAsPayload(t *testing.T) {
+	// given
+	rocket := Stage{Name: "lower", Modules: []int{14}, Stages: []Stage{{Name: "upper", Modules: []int{1969}}}}
+
+	// when
+	result := computeStageFuel(rocket)
+
+	// then
+	upper := StageFuel{"upper", 1969, 0, 966, 2935, nil}
+	lowerFuel := 2 + computeFuelMassFromModuleMass(2935)
+	assert.Equal(t, StageFuel{"lower", 14, 2935, lowerFuel, 14 + 2935 + lowerFuel, []StageFuel{upper}}, result)
+	assert.Equal(t, 966+lowerFuel, result.totalFuel())
+}
+
+func TestShouldParseRocketFromJSON(t *testing.T) {
+	// given
+	input := `{"name": "lower", "modules": [14], "stages": [{"name": "upper", "modules": [1969]}]}`
+
+	// when
+	rocket, err1 := parseRocket(input)
+	_, err2 := parseRocket(`{"name": "lower", "stages": [{"name": "upper", "modules": [-1]}]}`)
+	_, err3 := parseRocket(`{"name": `)
+
+	// then
+	assert.Nil(t, err1)
+	assert.Equal(t, Stage{Name: "lower", Modules: []int{14}, Stages: []Stage{{Name: "upper", Modules: []int{1969}}}},
+		rocket)
+	assert.EqualError(t, err2, "stage \"lower/upper\": negative module mass -1")
+	assert.Error(t, err3)
+}
+
+func TestShouldFormatStageFuel(t *testing.T) {
+	// given
+	rocket := Stage{Name: "lower", Modules: []int{14}, Stages: []Stage{{Name: "upper", Modules: []int{1969}}}}
+
+	// when
+	result := formatStageFuel(computeStageFuel(rocket))
+
+	// then
+	assert.Equal(t, "lower: dry mass 14, payload 2935, fuel 1449, total mass 4398\n"+
+		"  upper: dry mass 1969, payload 0, fuel 966, total mass 2935\n"+
+		"Total fuel >> 2415\n", result)
+}
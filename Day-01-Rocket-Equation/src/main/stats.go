@@ -0,0 +1,214 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const histogramBarWidth = 40
+
+var reportedPercentiles = []int{25, 75, 90, 95, 99}
+
+type Distribution struct {
+	Count       int            `json:"count"`
+	Min         int            `json:"min"`
+	Max         int            `json:"max"`
+	Mean        float64        `json:"mean"`
+	Median      float64        `json:"median"`
+	Percentiles map[string]int `json:"percentiles"`
+}
+
+// HistogramBin counts modules with fuel in [From, To), the last bin also holds To.
+type HistogramBin struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
+
+// ModuleShare is a module, numbered from 1 in manifest order, with its part of the total fuel.
+type ModuleShare struct {
+	Module int     `json:"module"`
+	Mass   int     `json:"mass"`
+	Fuel   int     `json:"fuel"`
+	Share  float64 `json:"share"`
+}
+
+// Coverage is the smallest set of modules whose fuel reaches the requested share of the total.
+type Coverage struct {
+	Share   float64       `json:"share"`
+	Modules []ModuleShare `json:"modules"`
+}
+
+type FuelStats struct {
+	Mass       Distribution   `json:"mass"`
+	Fuel       Distribution   `json:"fuel"`
+	TotalFuel  int            `json:"totalFuel"`
+	Histogram  []HistogramBin `json:"histogram"`
+	TopModules []ModuleShare  `json:"topModules"`
+	Coverage   Coverage       `json:"coverage"`
+}
+
+func computeFuelStats(masses []int, bins, top int, coverageShare float64) (FuelStats, error) {
+	if len(masses) == 0 {
+		return FuelStats{}, errors.New("no modules in manifest")
+	}
+	if bins <= 0 || top < 0 || coverageShare <= 0 || coverageShare > 1 {
+		return FuelStats{}, fmt.Errorf("invalid stats options: bins %d, top %d, coverage %v", bins, top, coverageShare)
+	}
+	fuels := make([]int, len(masses))
+	modules := make([]ModuleShare, len(masses))
+	totalFuel := 0
+	for i, mass := range masses {
+		fuels[i] = computeFuelMassFromModuleMass(mass)
+		totalFuel += fuels[i]
+	}
+	for i, mass := range masses {
+		share := 0.0
+		if totalFuel > 0 {
+			share = float64(fuels[i]) / float64(totalFuel)
+		}
+		modules[i] = ModuleShare{i + 1, mass, fuels[i], share}
+	}
+	sort.SliceStable(modules, func(i, j int) bool {
+		return modules[i].Fuel > modules[j].Fuel
+	})
+	if top > len(modules) {
+		top = len(modules)
+	}
+	return FuelStats{
+		Mass:       computeDistribution(masses),
+		Fuel:       computeDistribution(fuels),
+		TotalFuel:  totalFuel,
+		Histogram:  computeHistogram(fuels, bins),
+		TopModules: modules[:top],
+		Coverage:   computeCoverage(modules, totalFuel, coverageShare),
+	}, nil
+}
+
+func computeDistribution(values []int) Distribution {
+	sorted := make([]int, len(values))
+	copy(sorted, values)
+	sort.Ints(sorted)
+	sum := 0
+	for _, value := range sorted {
+		sum += value
+	}
+	count := len(sorted)
+	median := float64(sorted[count/2])
+	if count%2 == 0 {
+		median = float64(sorted[count/2-1]+sorted[count/2]) / 2
+	}
+	percentiles := make(map[string]int)
+	for _, percentile := range reportedPercentiles {
+		percentiles[fmt.Sprintf("p%d", percentile)] = nearestRank(sorted, percentile)
+	}
+	return Distribution{count, sorted[0], sorted[count-1], float64(sum) / float64(count), median, percentiles}
+}
+
+// nearestRank returns the smallest value with at least percentile% of the values not greater than it.
+func nearestRank(sorted []int, percentile int) int {
+	rank := (percentile*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// computeHistogram splits [min, max] of the values into at most bins bins of equal width.
+func computeHistogram(values []int, bins int) []HistogramBin {
+	min, max := values[0], values[0]
+	for _, value := range values {
+		if value < min {
+			min = value
+		}
+		if value > max {
+			max = value
+		}
+	}
+	if max-min+1 < bins {
+		bins = max - min + 1
+	}
+	width := (max - min + bins) / bins
+	// rounding the width up may leave fewer bins needed to reach max
+	bins = (max - min + width) / width
+	histogram := make([]HistogramBin, bins)
+	for i := range histogram {
+		histogram[i] = HistogramBin{min + i*width, min + (i+1)*width, 0}
+	}
+	histogram[bins-1].To = max
+	for _, value := range values {
+		bin := (value - min) / width
+		if bin >= bins {
+			bin = bins - 1
+		}
+		histogram[bin].Count++
+	}
+	return histogram
+}
+
+// computeCoverage takes modules sorted by descending fuel, so their shortest prefix is the smallest covering set.
+func computeCoverage(sortedModules []ModuleShare, totalFuel int, share float64) Coverage {
+	covered := 0
+	for i, module := range sortedModules {
+		covered += module.Fuel
+		if float64(covered) >= share*float64(totalFuel) {
+			return Coverage{share, sortedModules[:i+1]}
+		}
+	}
+	return Coverage{share, sortedModules}
+}
+
+func formatFuelStatsJSON(stats FuelStats) (string, error) {
+	content, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content) + "\n", nil
+}
+
+func formatFuelStatsText(stats FuelStats) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("modules: %d, total fuel: %d\n", stats.Mass.Count, stats.TotalFuel))
+	builder.WriteString(fmt.Sprintf("mass: %s\n", formatDistribution(stats.Mass)))
+	builder.WriteString(fmt.Sprintf("fuel: %s\n", formatDistribution(stats.Fuel)))
+	builder.WriteString("fuel histogram:\n")
+	largestBin := 0
+	for _, bin := range stats.Histogram {
+		if bin.Count > largestBin {
+			largestBin = bin.Count
+		}
+	}
+	for i, bin := range stats.Histogram {
+		closing := ")"
+		if i == len(stats.Histogram)-1 {
+			closing = "]"
+		}
+		bar := strings.Repeat("#", bin.Count*histogramBarWidth/largestBin)
+		builder.WriteString(fmt.Sprintf("  [%d, %d%s %d %s\n", bin.From, bin.To, closing, bin.Count, bar))
+	}
+	builder.WriteString(fmt.Sprintf("top %d modules by fuel:\n", len(stats.TopModules)))
+	for _, module := range stats.TopModules {
+		builder.WriteString(fmt.Sprintf("  %s\n", formatModuleShare(module)))
+	}
+	moduleNumbers := make([]string, len(stats.Coverage.Modules))
+	for i, module := range stats.Coverage.Modules {
+		moduleNumbers[i] = fmt.Sprintf("#%d", module.Module)
+	}
+	builder.WriteString(fmt.Sprintf("%.0f%% of total fuel comes from %d modules: %s\n", stats.Coverage.Share*100,
+		len(stats.Coverage.Modules), strings.Join(moduleNumbers, ", ")))
+	return builder.String()
+}
+
+func formatDistribution(distribution Distribution) string {
+	percentiles := distribution.Percentiles
+	return fmt.Sprintf("min %d, p25 %d, median %.1f, mean %.2f, p75 %d, p90 %d, p95 %d, p99 %d, max %d",
+		distribution.Min, percentiles["p25"], distribution.Median, distribution.Mean, percentiles["p75"],
+		percentiles["p90"], percentiles["p95"], percentiles["p99"], distribution.Max)
+}
+
+func formatModuleShare(module ModuleShare) string {
+	return fmt.Sprintf("#%d mass %d fuel %d (%.2f%%)", module.Module, module.Mass, module.Fuel, module.Share*100)
+}
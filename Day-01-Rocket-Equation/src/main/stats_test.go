@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldComputeDistribution(t *testing.T) {
+	// given
+	values := []int{7, 1, 3, 9, 5, 2, 8, 4, 6, 10}
+
+	// when
+	distribution := computeDistribution(values)
+
+	// then
+	assert.Equal(t, Distribution{10, 1, 10, 5.5, 5.5,
+		map[string]int{"p25": 3, "p75": 8, "p90": 9, "p95": 10, "p99": 10}}, distribution)
+	assert.Equal(t, []int{7, 1, 3, 9, 5, 2, 8, 4, 6, 10}, values)
+}
+
+func TestShouldComputeHistogram(t *testing.T) {
+	// when
+	histogram1 := computeHistogram([]int{0, 1, 5, 9, 10}, 2)
+	histogram2 := computeHistogram([]int{4, 4, 5}, 10)
+	histogram3 := computeHistogram([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 5)
+
+	// then
+	assert.Equal(t, []HistogramBin{{0, 6, 3}, {6, 10, 2}}, histogram1)
+	assert.Equal(t, []HistogramBin{{4, 5, 2}, {5, 5, 1}}, histogram2)
+	assert.Equal(t, []HistogramBin{{0, 3, 3}, {3, 6, 3}, {6, 9, 3}, {9, 10, 2}}, histogram3)
+}
+
+func TestShouldFindModulesDominatingFuel(t *testing.T) {
+	// given
+	masses := []int{14, 1969, 100756, 12}
+
+	// when
+	stats, err := computeFuelStats(masses, 3, 2, 0.8)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, 51316, stats.TotalFuel)
+	assert.Equal(t, 2, len(stats.TopModules))
+	assert.Equal(t, 3, stats.TopModules[0].Module)
+	assert.Equal(t, 2, stats.TopModules[1].Module)
+	assert.Equal(t, 1, len(stats.Coverage.Modules))
+	assert.InDelta(t, 50346.0/51316.0, stats.Coverage.Modules[0].Share, 1e-9)
+}
+
+func TestShouldRejectEmptyManifestStats(t *testing.T) {
+	// when
+	_, err1 := computeFuelStats(nil, 10, 5, 0.8)
+	_, err2 := computeFuelStats([]int{14}, 0, 5, 0.8)
+	_, err3 := computeFuelStats([]int{14}, 10, 5, 1.5)
+
+	// then
+	assert.Error(t, err1)
+	assert.Error(t, err2)
+	assert.Error(t, err3)
+}
+
+func TestShouldFormatFuelStatsAsText(t *testing.T) {
+	// given
+	stats, _ := computeFuelStats([]int{14, 1969, 100756, 12}, 2, 1, 0.8)
+
+	// when
+	result := formatFuelStatsText(stats)
+
+	// then
+	assert.Equal(t, "modules: 4, total fuel: 51316\n"+
+		"mass: min 12, p25 12, median 991.5, mean 25687.75, p75 1969, p90 100756, p95 100756, p99 100756, max 100756\n"+
+		"fuel: min 2, p25 2, median 484.0, mean 12829.00, p75 966, p90 50346, p95 50346, p99 50346, max 50346\n"+
+		"fuel histogram:\n"+
+		"  [2, 25175) 3 ########################################\n"+
+		"  [25175, 50346] 1 #############\n"+
+		"top 1 modules by fuel:\n"+
+		"  #3 mass 100756 fuel 50346 (98.11%)\n"+
+		"80% of total fuel comes from 1 modules: #3\n", result)
+}
+
+func TestShouldFormatFuelStatsAsJSON(t *testing.T) {
+	// given
+	stats, _ := computeFuelStats([]int{14, 1969}, 2, 1, 0.8)
+
+	// when
+	result, err := formatFuelStatsJSON(stats)
+
+	// then
+	assert.Nil(t, err)
+	var decoded FuelStats
+	assert.Nil(t, json.Unmarshal([]byte(result), &decoded))
+	assert.Equal(t, stats, decoded)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FuelTrace is the chain of fuel increments computeFuel adds up for a module, e.g. 654, 216, 70, 21 and 5 for a
+// mass of 1969.
+type FuelTrace struct {
+	Mass       int   `json:"mass"`
+	Increments []int `json:"increments"`
+	Total      int   `json:"total"`
+}
+
+func traceFuelMassFromModuleMass(mass int) FuelTrace {
+	return part2Model.traceFuel(mass)
+}
+
+func (model FuelModel) traceFuel(mass int) FuelTrace {
+	trace := FuelTrace{Mass: mass, Increments: []int{}}
+	model.walkFuel(mass, func(fuel int) {
+		trace.Increments = append(trace.Increments, fuel)
+		trace.Total += fuel
+	})
+	return trace
+}
+
+func traceFuelForManifest(masses []int) []FuelTrace {
+	traces := make([]FuelTrace, len(masses))
+	for i, mass := range masses {
+		traces[i] = traceFuelMassFromModuleMass(mass)
+	}
+	return traces
+}
+
+// String shows the trace the way the puzzle explains it: 1969 → 654 → 216 → 70 → 21 → 5 → 0 = 966.
+func (trace FuelTrace) String() string {
+	steps := []string{fmt.Sprintf("%d", trace.Mass)}
+	for _, increment := range trace.Increments {
+		steps = append(steps, fmt.Sprintf("%d", increment))
+	}
+	steps = append(steps, "0")
+	return fmt.Sprintf("%s = %d", strings.Join(steps, " → "), trace.Total)
+}
+
+func formatFuelTracesJSON(traces []FuelTrace) (string, error) {
+	content, err := json.MarshalIndent(traces, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content) + "\n", nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldTraceFuelIncrements(t *testing.T) {
+	// when
+	trace1 := traceFuelMassFromModuleMass(1969)
+	trace2 := traceFuelMassFromModuleMass(14)
+	trace3 := traceFuelMassFromModuleMass(1)
+	trace4 := part1Model.traceFuel(1969)
+
+	// then
+	assert.Equal(t, FuelTrace{1969, []int{654, 216, 70, 21, 5}, 966}, trace1)
+	assert.Equal(t, FuelTrace{14, []int{2}, 2}, trace2)
+	assert.Equal(t, FuelTrace{1, []int{}, 0}, trace3)
+	assert.Equal(t, FuelTrace{1969, []int{654}, 654}, trace4)
+}
+
+func TestShouldMatchComputedFuelInTrace(t *testing.T) {
+	// given
+	masses := []int{12, 14, 1969, 100756, 86055}
+
+	// when
+	traces := traceFuelForManifest(masses)
+
+	// then
+	for i, mass := range masses {
+		assert.Equal(t, computeFuelMassFromModuleMass(mass), traces[i].Total)
+	}
+}
+
+func TestShouldFormatFuelTrace(t *testing.T) {
+	// when
+	result1 := traceFuelMassFromModuleMass(1969).String()
+	result2 := traceFuelMassFromModuleMass(100756).String()
+	result3 := traceFuelMassFromModuleMass(2).String()
+
+	// then
+	assert.Equal(t, "1969 → 654 → 216 → 70 → 21 → 5 → 0 = 966", result1)
+	assert.Equal(t, "100756 → 33583 → 11192 → 3728 → 1240 → 411 → 135 → 43 → 12 → 2 → 0 = 50346", result2)
+	assert.Equal(t, "2 → 0 = 0", result3)
+}
+
+func TestShouldExportFuelTracesAsJSON(t *testing.T) {
+	// when
+	result, err := formatFuelTracesJSON(traceFuelForManifest([]int{14}))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n  {\n    \"mass\": 14,\n    \"increments\": [\n      2\n    ],\n    \"total\": 2\n  }\n]\n", result)
+}
@@ -0,0 +1,85 @@
+package main
+
+import "math/big"
+
+// FuelCache memoises the fuel of every mass seen so far, including the intermediate fuel masses of the chain, so
+// manifests repeating masses compute each of them once.
+type FuelCache struct {
+	model FuelModel
+	fuel  map[int]int
+}
+
+func newFuelCache(model FuelModel) *FuelCache {
+	return &FuelCache{model, make(map[int]int)}
+}
+
+func (cache *FuelCache) computeFuel(mass int) int {
+	if fuel, found := cache.fuel[mass]; found {
+		return fuel
+	}
+	result := 0
+	if fuel, burnt := cache.model.fuelIncrement(mass); burnt {
+		result = fuel
+		if cache.model.fuelForFuel {
+			result += cache.computeFuel(fuel)
+		}
+	}
+	cache.fuel[mass] = result
+	return result
+}
+
+func computeFuelSumMemoised(masses []int) int {
+	cache := newFuelCache(part2Model)
+	result := 0
+	for _, mass := range masses {
+		result += cache.computeFuel(mass)
+	}
+	return result
+}
+
+// computeFuelIteratively gives the same result as computeFuel with a loop, so the length of the fuel-for-fuel
+// chain is not limited by the stack, e.g. for a divisor of 1.
+func (model FuelModel) computeFuelIteratively(mass int) int {
+	result := 0
+	model.walkFuel(mass, func(fuel int) {
+		result += fuel
+	})
+	return result
+}
+
+func computeFuelMassIteratively(mass int) int {
+	return part2Model.computeFuelIteratively(mass)
+}
+
+// computeFuelBig is computeFuelIteratively for masses beyond int64.
+func (model FuelModel) computeFuelBig(mass *big.Int) *big.Int {
+	result := new(big.Int)
+	minimumBurn := big.NewInt(int64(model.minimumBurn))
+	for fuel := model.burnBig(mass); fuel.Sign() > 0 && fuel.Cmp(minimumBurn) >= 0; fuel = model.burnBig(fuel) {
+		result.Add(result, fuel)
+		if !model.fuelForFuel {
+			break
+		}
+	}
+	return result
+}
+
+func computeFuelMassBig(mass *big.Int) *big.Int {
+	return part2Model.computeFuelBig(mass)
+}
+
+func (model FuelModel) burnBig(mass *big.Int) *big.Int {
+	divisor := big.NewInt(int64(model.divisor))
+	fuel := new(big.Int)
+	// Div rounds towards negative infinity for a positive divisor
+	switch model.rounding {
+	case Ceil:
+		fuel.Div(new(big.Int).Neg(mass), divisor).Neg(fuel)
+	case Nearest:
+		doubled := new(big.Int).Lsh(mass, 1)
+		fuel.Div(doubled.Add(doubled, divisor), new(big.Int).Lsh(divisor, 1))
+	default:
+		fuel.Div(mass, divisor)
+	}
+	return fuel.Sub(fuel, big.NewInt(int64(model.offset)))
+}
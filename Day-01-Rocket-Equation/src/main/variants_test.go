@@ -0,0 +1,124 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+const benchmarkModules = 1000000
+
+func TestShouldMatchRecursiveFuelInEveryVariant(t *testing.T) {
+	// given
+	models := []FuelModel{part1Model, part2Model,
+		{divisor: 4, offset: 1, rounding: Ceil, fuelForFuel: true, minimumBurn: 3},
+		{divisor: 7, offset: 0, rounding: Nearest, fuelForFuel: true}}
+	masses := []int{0, 1, 8, 9, 14, 1969, 100756, 987654321}
+
+	for _, model := range models {
+		cache := newFuelCache(model)
+		for _, mass := range masses {
+			// when
+			expected := model.computeFuel(mass)
+			memoised := cache.computeFuel(mass)
+			iterative := model.computeFuelIteratively(mass)
+			bigFuel := model.computeFuelBig(big.NewInt(int64(mass)))
+
+			// then
+			assert.Equal(t, expected, memoised)
+			assert.Equal(t, expected, iterative)
+			assert.Equal(t, int64(expected), bigFuel.Int64())
+		}
+	}
+}
+
+func TestShouldComputeFuelWithoutRecursionForLongChains(t *testing.T) {
+	// given
+	model := FuelModel{divisor: 1, offset: 1, rounding: Floor, fuelForFuel: true}
+
+	// when
+	result := model.computeFuelIteratively(10000000)
+
+	// then
+	assert.Equal(t, 9999999*10000000/2, result)
+}
+
+func TestShouldComputeFuelBeyondInt64(t *testing.T) {
+	// given
+	mass, _ := new(big.Int).SetString("100000000000000000000000000000", 10)
+
+	// when
+	result := computeFuelMassBig(mass)
+
+	// then
+	expected, _ := new(big.Int).SetString("49999999999999999999999999795", 10)
+	assert.Equal(t, expected.String(), result.String())
+}
+
+func TestShouldComputeMemoisedFuelSum(t *testing.T) {
+	// given
+	masses := []int{30, 20, 1969, 1969, 30}
+
+	// when
+	result := computeFuelSumMemoised(masses)
+
+	// then
+	assert.Equal(t, computeFuelSum(masses), result)
+}
+
+// benchmarkMasses repeats a thousand distinct masses, like real manifests do.
+func benchmarkMasses() []int {
+	random := rand.New(rand.NewSource(1))
+	distinct := make([]int, 1000)
+	for i := range distinct {
+		distinct[i] = 50000 + random.Intn(100000)
+	}
+	masses := make([]int, benchmarkModules)
+	for i := range masses {
+		masses[i] = distinct[random.Intn(len(distinct))]
+	}
+	return masses
+}
+
+func BenchmarkRecursiveFuelSum(b *testing.B) {
+	masses := benchmarkMasses()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		computeFuelSum(masses)
+	}
+}
+
+func BenchmarkMemoisedFuelSum(b *testing.B) {
+	masses := benchmarkMasses()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		computeFuelSumMemoised(masses)
+	}
+}
+
+func BenchmarkIterativeFuelSum(b *testing.B) {
+	masses := benchmarkMasses()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := 0
+		for _, mass := range masses {
+			result += computeFuelMassIteratively(mass)
+		}
+	}
+}
+
+func BenchmarkBigFuelSum(b *testing.B) {
+	masses := benchmarkMasses()
+	bigMasses := make([]*big.Int, len(masses))
+	for i, mass := range masses {
+		bigMasses[i] = big.NewInt(int64(mass))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result := new(big.Int)
+		for _, mass := range bigMasses {
+			result.Add(result, computeFuelMassBig(mass))
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// runCommand dispatches the tools available next to the puzzle solution, e.g. `go run ./src/main serve`.
+func runCommand(name string, args []string) error {
+	switch name {
+	case "serve":
+		return serveCommand(args)
+	case "patch":
+		return patchCommand(args)
+	case "icdiff":
+		return icdiffCommand(args)
+	}
+	return fmt.Errorf("unknown command %q", name)
+}
+
+func serveCommand(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "address to listen on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	log.Printf("Intcode service listening on %v", *addr)
+	return http.ListenAndServe(*addr, newIntCodeServer())
+}
+
+// patchCommand prints the program with the patch file applied, e.g. `patch -symbols src/data/symbols
+// src/data/input src/data/alarm.patch`.
+func patchCommand(args []string) error {
+	flags := flag.NewFlagSet("patch", flag.ContinueOnError)
+	symbolsPath := flags.String("symbols", "", "file with label=address lines used to resolve labels")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("usage: patch [-symbols file] <program> <patch>")
+	}
+	patched, err := loadPatchedProgram(flags.Arg(0), flags.Arg(1), *symbolsPath)
+	if err != nil {
+		return err
+	}
+	fmt.Println(joinIntCode(patched, ","))
+	return nil
+}
+
+// icdiffCommand prints the cells that differ between two programs or memory dumps.
+func icdiffCommand(args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: icdiff <program> <program>")
+	}
+	before, err := loadProgram(args[0])
+	if err != nil {
+		return err
+	}
+	after, err := loadProgram(args[1])
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatIntCodeDiff(before, after))
+	return nil
+}
+
+func loadProgram(path string) ([]int, error) {
+	input, err := getInput(path)
+	if err != nil {
+		return nil, err
+	}
+	intCode, err := parseIntCode(input)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	return intCode, nil
+}
+
+func loadPatchedProgram(programPath, patchPath, symbolsPath string) ([]int, error) {
+	intCode, err := loadProgram(programPath)
+	if err != nil {
+		return nil, err
+	}
+	symbols := make(map[string]int)
+	if symbolsPath != "" {
+		symbolsInput, err := getInput(symbolsPath)
+		if err != nil {
+			return nil, err
+		}
+		if symbols, err = parseSymbols(symbolsInput); err != nil {
+			return nil, fmt.Errorf("%v: %v", symbolsPath, err)
+		}
+	}
+	patchInput, err := getInput(patchPath)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := parsePatch(patchInput, symbols)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", patchPath, err)
+	}
+	return applyPatch(intCode, patch)
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const goldenPath = "../data/golden"
+const goldenTimeout = 5 * time.Second
+
+var update = flag.Bool("update", false, "rewrite memory.txt and output.txt of every golden fixture")
+var goldenLimits = Limits{maxInstructions: 10000000, maxMemory: 1 << 24}
+
+// TestShouldMatchGoldenFixtures runs every directory under src/data/golden holding a program.ic and compares the
+// final memory and outputs with memory.txt and output.txt. Run `go test ./... -update` to rewrite them.
+func TestShouldMatchGoldenFixtures(t *testing.T) {
+	programs, err := filepath.Glob(filepath.Join(goldenPath, "*", "program.ic"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(programs) == 0 {
+		t.Fatalf("no golden fixtures found in %v", goldenPath)
+	}
+	for _, program := range programs {
+		dir := filepath.Dir(program)
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			runGoldenFixture(t, dir)
+		})
+	}
+}
+
+func runGoldenFixture(t *testing.T, dir string) {
+	// given
+	programInput, err := getInput(filepath.Join(dir, "program.ic"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	intCode, err := parseIntCode(programInput)
+	if err != nil {
+		t.Fatalf("program.ic: %v", err)
+	}
+	inputs, err := readGoldenValues(filepath.Join(dir, "input.txt"))
+	if err != nil {
+		t.Fatalf("input.txt: %v", err)
+	}
+	machine := newIntCodeMachine(intCode, goldenLimits)
+	machine.readInput = func() (int, error) {
+		if len(inputs) == 0 {
+			return 0, ErrNoInput
+		}
+		value := inputs[0]
+		inputs = inputs[1:]
+		return value, nil
+	}
+	var outputs []int
+	machine.writeOutput = func(value int) error {
+		outputs = append(outputs, value)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), goldenTimeout)
+	defer cancel()
+
+	// when
+	err = machine.run(ctx)
+
+	// then
+	assert.Nil(t, err)
+	memoryPath := filepath.Join(dir, "memory.txt")
+	outputPath := filepath.Join(dir, "output.txt")
+	if *update {
+		writeGoldenFile(t, memoryPath, joinIntCode(machine.memory, ","))
+		if len(outputs) > 0 || fileExists(outputPath) {
+			writeGoldenFile(t, outputPath, joinIntCode(outputs, "\n"))
+		}
+		return
+	}
+	expectedMemory, memoryErr := readGoldenValues(memoryPath)
+	expectedOutputs, outputErr := readGoldenValues(outputPath)
+	if memoryErr != nil || outputErr != nil {
+		t.Fatalf("cannot read goldens (memory.txt: %v, output.txt: %v)", memoryErr, outputErr)
+	}
+	if !fileExists(memoryPath) && !fileExists(outputPath) {
+		t.Fatalf("neither memory.txt nor output.txt found in %v, run with -update to create them", dir)
+	}
+	if fileExists(memoryPath) {
+		assert.Equal(t, expectedMemory, machine.memory, "memory.txt")
+	}
+	if fileExists(outputPath) {
+		assert.Equal(t, expectedOutputs, outputs, "output.txt")
+	}
+}
+
+// readGoldenValues reads integers separated by commas or whitespace. A missing file holds no values.
+func readGoldenValues(path string) ([]int, error) {
+	if !fileExists(path) {
+		return nil, nil
+	}
+	content, err := getInput(path)
+	if err != nil {
+		return nil, err
+	}
+	var values []int
+	fields := strings.FieldsFunc(content, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\r' || r == '\n'
+	})
+	for _, field := range fields {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func writeGoldenFile(t *testing.T, path string, content string) {
+	if err := ioutil.WriteFile(path, []byte(content+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Instruction struct {
+	address, length int
+	text            string
+}
+
+type CellDiff struct {
+	address int
+	// before and after are nil when the cell exists in only one of the programs
+	before, after *int
+}
+
+// disassemble decodes the program from position 0. Cells that do not start a known instruction are shown as data.
+func disassemble(intCode []int) []Instruction {
+	var instructions []Instruction
+	for i := 0; i < len(intCode); {
+		instruction := decodeInstruction(intCode, i)
+		instructions = append(instructions, instruction)
+		i += instruction.length
+	}
+	return instructions
+}
+
+func decodeInstruction(intCode []int, i int) Instruction {
+	switch intCode[i] {
+	case 1, 2:
+		if i+3 < len(intCode) {
+			name := "ADD"
+			if intCode[i] == 2 {
+				name = "MUL"
+			}
+			return Instruction{i, 4, fmt.Sprintf("%s [%d] [%d] -> [%d]", name, intCode[i+1], intCode[i+2], intCode[i+3])}
+		}
+	case 3:
+		if i+1 < len(intCode) {
+			return Instruction{i, 2, fmt.Sprintf("IN -> [%d]", intCode[i+1])}
+		}
+	case 4:
+		if i+1 < len(intCode) {
+			return Instruction{i, 2, fmt.Sprintf("OUT [%d]", intCode[i+1])}
+		}
+	case 99:
+		return Instruction{i, 1, "HLT"}
+	}
+	return Instruction{i, 1, fmt.Sprintf("DATA %d", intCode[i])}
+}
+
+// instructionAt returns the disassembled instruction covering the address.
+func instructionAt(instructions []Instruction, address int) *Instruction {
+	for i := range instructions {
+		if address >= instructions[i].address && address < instructions[i].address+instructions[i].length {
+			return &instructions[i]
+		}
+	}
+	return nil
+}
+
+func diffIntCode(before, after []int) []CellDiff {
+	var diffs []CellDiff
+	length := len(before)
+	if len(after) > length {
+		length = len(after)
+	}
+	for i := 0; i < length; i++ {
+		var beforeCell, afterCell *int
+		if i < len(before) {
+			beforeCell = &before[i]
+		}
+		if i < len(after) {
+			afterCell = &after[i]
+		}
+		if beforeCell == nil || afterCell == nil || *beforeCell != *afterCell {
+			diffs = append(diffs, CellDiff{i, beforeCell, afterCell})
+		}
+	}
+	return diffs
+}
+
+// formatIntCodeDiff prints every differing cell followed by the instruction containing it in both programs.
+func formatIntCodeDiff(before, after []int) string {
+	diffs := diffIntCode(before, after)
+	if len(diffs) == 0 {
+		return "no differences\n"
+	}
+	beforeInstructions := disassemble(before)
+	afterInstructions := disassemble(after)
+	var builder strings.Builder
+	for _, diff := range diffs {
+		builder.WriteString(fmt.Sprintf("@%d: %s -> %s\n", diff.address, formatCell(diff.before),
+			formatCell(diff.after)))
+		builder.WriteString(fmt.Sprintf("  - %s\n", formatInstruction(instructionAt(beforeInstructions, diff.address))))
+		builder.WriteString(fmt.Sprintf("  + %s\n", formatInstruction(instructionAt(afterInstructions, diff.address))))
+	}
+	return builder.String()
+}
+
+func formatCell(cell *int) string {
+	if cell == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%d", *cell)
+}
+
+func formatInstruction(instruction *Instruction) string {
+	if instruction == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%04d %s", instruction.address, instruction.text)
+}
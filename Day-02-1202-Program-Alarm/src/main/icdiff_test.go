@@ -0,0 +1,61 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldDisassembleProgram(t *testing.T) {
+	// given
+	intCode := []int{1, 9, 10, 3, 3, 9, 4, 3, 99, 30, 2}
+
+	// when
+	instructions := disassemble(intCode)
+
+	// then
+	assert.Equal(t, []Instruction{
+		{0, 4, "ADD [9] [10] -> [3]"},
+		{4, 2, "IN -> [9]"},
+		{6, 2, "OUT [3]"},
+		{8, 1, "HLT"},
+		{9, 1, "DATA 30"},
+		{10, 1, "DATA 2"},
+	}, instructions)
+}
+
+func TestShouldDiffProgramsOfDifferentLength(t *testing.T) {
+	// given
+	before := []int{1, 0, 0, 3, 99}
+	after := []int{1, 12, 0, 3, 99, 7}
+
+	// when
+	diffs := diffIntCode(before, after)
+
+	// then
+	assert.Equal(t, 2, len(diffs))
+	assert.Equal(t, 1, diffs[0].address)
+	assert.Equal(t, 0, *diffs[0].before)
+	assert.Equal(t, 12, *diffs[0].after)
+	assert.Equal(t, 5, diffs[1].address)
+	assert.Nil(t, diffs[1].before)
+	assert.Equal(t, 7, *diffs[1].after)
+}
+
+func TestShouldFormatDiffWithDisassemblyContext(t *testing.T) {
+	// given
+	before := []int{1, 0, 0, 3, 99}
+	after := []int{2, 0, 0, 3, 99, 7}
+
+	// when
+	result1 := formatIntCodeDiff(before, after)
+	result2 := formatIntCodeDiff(before, before)
+
+	// then
+	assert.Equal(t, "@0: 1 -> 2\n"+
+		"  - 0000 ADD [0] [0] -> [3]\n"+
+		"  + 0000 MUL [0] [0] -> [3]\n"+
+		"@5: <none> -> 7\n"+
+		"  - <none>\n"+
+		"  + 0005 DATA 7\n", result1)
+	assert.Equal(t, "no differences\n", result2)
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInstructionLimitExceeded = errors.New("instruction limit exceeded")
+	ErrTimeout                  = errors.New("execution timed out")
+	ErrMemoryLimitExceeded      = errors.New("memory limit exceeded")
+	ErrUnknownOpCode            = errors.New("unknown opcode")
+	ErrInvalidAddress           = errors.New("invalid address")
+	ErrTruncatedInstruction     = errors.New("truncated instruction")
+	ErrNoInput                  = errors.New("no input available")
+)
+
+// defaultMaxMemory caps the memory when Limits does not, so a write to a huge address fails instead of allocating it.
+const defaultMaxMemory = 1 << 24
+
+// Limits bounds a single Intcode execution. A zero maxInstructions means no limit, a zero maxMemory falls back to
+// defaultMaxMemory. The wall-clock timeout is not part of Limits, it comes from the context passed to run.
+type Limits struct {
+	maxInstructions, maxMemory int
+}
+
+func (limits Limits) memoryLimit() int {
+	if limits.maxMemory <= 0 {
+		return defaultMaxMemory
+	}
+	return limits.maxMemory
+}
+
+type IntCodeMachine struct {
+	memory           []int
+	pointer          int
+	instructionCount int
+	limits           Limits
+	halted           bool
+	readInput        func() (int, error)
+	writeOutput      func(int) error
+}
+
+func newIntCodeMachine(intCode []int, limits Limits) *IntCodeMachine {
+	return &IntCodeMachine{memory: intCode, limits: limits}
+}
+
+// runIntCode executes the program until it halts or one of the limits is hit. On error the returned memory is the
+// state at the moment execution stopped.
+func runIntCode(ctx context.Context, intCode []int, limits Limits) ([]int, error) {
+	machine := newIntCodeMachine(intCode, limits)
+	err := machine.run(ctx)
+	return machine.memory, err
+}
+
+func (machine *IntCodeMachine) run(ctx context.Context) error {
+	if len(machine.memory) > machine.limits.memoryLimit() {
+		return fmt.Errorf("%w: program size %d, limit %d", ErrMemoryLimitExceeded, len(machine.memory),
+			machine.limits.memoryLimit())
+	}
+	for !machine.halted {
+		if err := machine.checkLimits(ctx); err != nil {
+			return err
+		}
+		if err := machine.step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (machine *IntCodeMachine) checkLimits(ctx context.Context) error {
+	if err := machine.checkContext(ctx); err != nil {
+		return err
+	}
+	if machine.limits.maxInstructions > 0 && machine.instructionCount >= machine.limits.maxInstructions {
+		return fmt.Errorf("%w: %d", ErrInstructionLimitExceeded, machine.limits.maxInstructions)
+	}
+	return nil
+}
+
+// checkContext reports a passed deadline as ErrTimeout, so waiting for an input times out like running does.
+func (machine *IntCodeMachine) checkContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %d instructions", ErrTimeout, machine.instructionCount)
+		}
+		return err
+	}
+	return nil
+}
+
+func (machine *IntCodeMachine) step() error {
+	if machine.pointer >= len(machine.memory) {
+		machine.halted = true
+		return nil
+	}
+	code := machine.memory[machine.pointer]
+	switch code {
+	case 1, 2:
+		if err := machine.prepareParameters(3); err != nil {
+			return err
+		}
+		if code == 1 {
+			machine.memory = computeAdding(machine.memory, machine.pointer)
+		} else {
+			machine.memory = computeMultiplying(machine.memory, machine.pointer)
+		}
+		machine.pointer += 4
+	case 3, 4:
+		if err := machine.prepareParameters(1); err != nil {
+			return err
+		}
+		if code == 3 {
+			if err := machine.computeInput(); err != nil {
+				return err
+			}
+		} else if err := machine.computeOutput(); err != nil {
+			return err
+		}
+		machine.pointer += 2
+	case 99:
+		machine.halted = true
+	default:
+		return fmt.Errorf("%w %d at position %d", ErrUnknownOpCode, code, machine.pointer)
+	}
+	machine.instructionCount++
+	return nil
+}
+
+func (machine *IntCodeMachine) computeInput() error {
+	if machine.readInput == nil {
+		return fmt.Errorf("%w at position %d", ErrNoInput, machine.pointer)
+	}
+	value, err := machine.readInput()
+	if err != nil {
+		return err
+	}
+	machine.memory[machine.memory[machine.pointer+1]] = value
+	return nil
+}
+
+func (machine *IntCodeMachine) computeOutput() error {
+	value := machine.memory[machine.memory[machine.pointer+1]]
+	if machine.writeOutput == nil {
+		return nil
+	}
+	return machine.writeOutput(value)
+}
+
+// prepareParameters makes sure every address used by the instruction at the current pointer is addressable, growing
+// the memory when the program refers past its end.
+func (machine *IntCodeMachine) prepareParameters(count int) error {
+	if machine.pointer+count >= len(machine.memory) {
+		return fmt.Errorf("%w at position %d", ErrTruncatedInstruction, machine.pointer)
+	}
+	for offset := 1; offset <= count; offset++ {
+		if err := machine.ensureAddressable(machine.memory[machine.pointer+offset]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (machine *IntCodeMachine) ensureAddressable(address int) error {
+	if address < 0 {
+		return fmt.Errorf("%w %d at position %d", ErrInvalidAddress, address, machine.pointer)
+	}
+	if address < len(machine.memory) {
+		return nil
+	}
+	if address >= machine.limits.memoryLimit() {
+		return fmt.Errorf("%w: address %d, limit %d", ErrMemoryLimitExceeded, address, machine.limits.memoryLimit())
+	}
+	machine.memory = append(machine.memory, make([]int, address+1-len(machine.memory))...)
+	return nil
+}
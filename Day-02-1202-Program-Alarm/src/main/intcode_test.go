@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestShouldRunIntCodeWithoutLimits(t *testing.T) {
+	// given
+	intCode := []int{1, 9, 10, 3, 2, 3, 11, 0, 99, 30, 40, 50}
+
+	// when
+	result, err := runIntCode(context.Background(), intCode, Limits{})
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3500, 9, 10, 70, 2, 3, 11, 0, 99, 30, 40, 50}, result)
+}
+
+func TestShouldStopWhenInstructionLimitExceeded(t *testing.T) {
+	// given
+	intCode := []int{1, 9, 10, 3, 2, 3, 11, 0, 99, 30, 40, 50}
+
+	// when
+	_, err1 := runIntCode(context.Background(), copyIntCode(intCode), Limits{maxInstructions: 2})
+	_, err2 := runIntCode(context.Background(), copyIntCode(intCode), Limits{maxInstructions: 3})
+
+	// then
+	assert.ErrorIs(t, err1, ErrInstructionLimitExceeded)
+	assert.Nil(t, err2)
+}
+
+func TestShouldStopWhenMemoryLimitExceeded(t *testing.T) {
+	// given
+	farWrite := []int{1, 0, 0, 100, 99}
+	bigProgram := []int{1, 0, 0, 0, 99}
+
+	// when
+	_, err1 := runIntCode(context.Background(), farWrite, Limits{maxMemory: 50})
+	_, err2 := runIntCode(context.Background(), bigProgram, Limits{maxMemory: 4})
+
+	// then
+	assert.ErrorIs(t, err1, ErrMemoryLimitExceeded)
+	assert.ErrorIs(t, err2, ErrMemoryLimitExceeded)
+}
+
+func TestShouldStopWritingToHugeAddressWithoutMemoryLimit(t *testing.T) {
+	// given
+	intCode := []int{1, 0, 0, 1099511627776, 99}
+
+	// when
+	_, err := runIntCode(context.Background(), intCode, Limits{})
+
+	// then
+	assert.ErrorIs(t, err, ErrMemoryLimitExceeded)
+	assert.EqualError(t, err, "memory limit exceeded: address 1099511627776, limit 16777216")
+}
+
+func TestShouldGrowMemoryWhenWritingPastTheEnd(t *testing.T) {
+	// given
+	intCode := []int{1, 0, 0, 6, 99}
+
+	// when
+	result, err := runIntCode(context.Background(), intCode, Limits{maxMemory: 10})
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 0, 0, 6, 99, 0, 2}, result)
+}
+
+func TestShouldStopWhenContextDeadlineExceeded(t *testing.T) {
+	// given
+	intCode := []int{1, 0, 0, 0, 99}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	// when
+	_, err := runIntCode(ctx, intCode, Limits{})
+
+	// then
+	assert.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestShouldReturnErrorsForMalformedPrograms(t *testing.T) {
+	// given
+	unknownOpCode := []int{7, 0, 0, 0, 99}
+	negativeAddress := []int{1, -1, 0, 0, 99}
+	truncated := []int{1, 0, 0}
+
+	// when
+	_, err1 := runIntCode(context.Background(), unknownOpCode, Limits{})
+	_, err2 := runIntCode(context.Background(), negativeAddress, Limits{})
+	_, err3 := runIntCode(context.Background(), truncated, Limits{})
+
+	// then
+	assert.ErrorIs(t, err1, ErrUnknownOpCode)
+	assert.ErrorIs(t, err2, ErrInvalidAddress)
+	assert.ErrorIs(t, err3, ErrTruncatedInstruction)
+}
+
+func TestShouldHaltWhenRunningOffTheEnd(t *testing.T) {
+	// given
+	intCode := []int{1, 0, 0, 0}
+
+	// when
+	result, err := runIntCode(context.Background(), intCode, Limits{})
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2, 0, 0, 0}, result)
+}
+
+func TestShouldReadInputAndWriteOutput(t *testing.T) {
+	// given
+	intCode := []int{3, 5, 4, 5, 99, 0}
+	machine := newIntCodeMachine(intCode, Limits{})
+	machine.readInput = func() (int, error) { return 42, nil }
+	var outputs []int
+	machine.writeOutput = func(value int) error {
+		outputs = append(outputs, value)
+		return nil
+	}
+
+	// when
+	err1 := machine.run(context.Background())
+	_, err2 := runIntCode(context.Background(), []int{3, 0, 99}, Limits{})
+
+	// then
+	assert.Nil(t, err1)
+	assert.Equal(t, []int{42}, outputs)
+	assert.Equal(t, 3, machine.instructionCount)
+	assert.ErrorIs(t, err2, ErrNoInput)
+}
+
+func TestShouldSkipPathologicalNounAndVerbCandidates(t *testing.T) {
+	// given
+	// most candidates overwrite the second opcode with garbage
+	intCode := []int{1, 0, 0, 4, 0, 0, 0, 0, 99}
+
+	// when
+	nounAndVerb1 := computeNounAndVerb(intCode, 2)
+	nounAndVerb2 := computeNounAndVerb(intCode, 1000)
+
+	// then
+	assert.Equal(t, Pair{0, 1}, nounAndVerb1)
+	assert.Equal(t, Pair{-1, -1}, nounAndVerb2)
+}
+
+func copyIntCode(intCode []int) []int {
+	result := make([]int, len(intCode))
+	copy(result, intCode)
+	return result
+}
@@ -1,33 +1,46 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const path = "/src/data/input"
-const noun = 12
-const verb = 2
+const alarmPatchPath = "/src/data/alarm.patch"
+const symbolsPath = "/src/data/symbols"
 const Part2OutputValue = 19690720
+const candidateTimeout = time.Second
+
+// nounVerbLimits keeps a single noun and verb candidate from stalling the whole search.
+var nounVerbLimits = Limits{maxInstructions: 1000000, maxMemory: 1 << 20}
 
 type Pair struct {
 	noun, verb int
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	fmt.Println("--- Day 2: 1202 Program Alarm ---")
 	pwd, _ := os.Getwd()
 	input, err := getInput(pwd + path)
 	if err != nil {
 		log.Fatal(err)
 	}
-	intCodePart1 := loadInputIntoTable(input)
-	intCodePart1[1] = noun
-	intCodePart1[2] = verb
+	intCodePart1, err := loadPatchedProgram(pwd+path, pwd+alarmPatchPath, pwd+symbolsPath)
+	if err != nil {
+		log.Fatal(err)
+	}
 	computingIntCode := computeIntCode(intCodePart1)
 	fmt.Println(fmt.Sprintf("Part 1 >> %d", computingIntCode[0]))
 
@@ -45,29 +58,39 @@ func getInput(path string) (string, error) {
 }
 
 func loadInputIntoTable(input string) []int {
+	intArray, err := parseIntCode(input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return intArray
+}
+
+func parseIntCode(input string) ([]int, error) {
 	input = strings.TrimSpace(input)
 	stringArray := strings.Split(input, ",")
 	var intArray []int
 	for _, it := range stringArray {
-		intValue, err := strconv.Atoi(it)
+		intValue, err := strconv.Atoi(strings.TrimSpace(it))
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
 		intArray = append(intArray, intValue)
 	}
-	return intArray
+	return intArray, nil
+}
+
+func joinIntCode(values []int, separator string) string {
+	stringValues := make([]string, len(values))
+	for i, value := range values {
+		stringValues[i] = strconv.Itoa(value)
+	}
+	return strings.Join(stringValues, separator)
 }
 
 func computeIntCode(intCode []int) []int {
-	for i := 0; i < len(intCode); i += 4 {
-		code := intCode[i]
-		if code == 1 {
-			intCode = computeAdding(intCode, i)
-		} else if code == 2 {
-			intCode = computeMultiplying(intCode, i)
-		} else if code == 99 {
-			break
-		}
+	intCode, err := runIntCode(context.Background(), intCode, Limits{})
+	if err != nil {
+		log.Fatal(err)
 	}
 	return intCode
 }
@@ -95,10 +118,20 @@ func computeNounAndVerb(intCode []int, outputValue int) Pair {
 			copy(testIntCode, intCode)
 			testIntCode[1] = i
 			testIntCode[2] = j
-			if computeIntCode(testIntCode)[0] == outputValue {
+			result, err := runCandidate(testIntCode)
+			if err != nil {
+				continue
+			}
+			if result[0] == outputValue {
 				return Pair{i, j}
 			}
 		}
 	}
 	return Pair{-1, -1}
 }
+
+func runCandidate(intCode []int) ([]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), candidateTimeout)
+	defer cancel()
+	return runIntCode(ctx, intCode, nounVerbLimits)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type PatchEntry struct {
+	address, value int
+}
+
+// parseSymbols reads `label=address` lines, e.g. `noun=1`, so patches can refer to addresses by name.
+func parseSymbols(input string) (map[string]int, error) {
+	symbols := make(map[string]int)
+	err := forEachAssignment(input, func(line int, key, value string) error {
+		address, err := strconv.Atoi(value)
+		if err != nil || address < 0 {
+			return fmt.Errorf("line %d: invalid address %q for symbol %q", line, value, key)
+		}
+		symbols[key] = address
+		return nil
+	})
+	return symbols, err
+}
+
+// parsePatch reads `address=value` lines. The address may also be a label defined in symbols.
+func parsePatch(input string, symbols map[string]int) ([]PatchEntry, error) {
+	var patch []PatchEntry
+	err := forEachAssignment(input, func(line int, key, value string) error {
+		address, err := strconv.Atoi(key)
+		if err != nil {
+			var found bool
+			if address, found = symbols[key]; !found {
+				return fmt.Errorf("line %d: unknown symbol %q", line, key)
+			}
+		}
+		intValue, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid value %q", line, value)
+		}
+		patch = append(patch, PatchEntry{address, intValue})
+		return nil
+	})
+	return patch, err
+}
+
+// forEachAssignment calls apply for every `key=value` line, skipping blank lines and `#` comments.
+func forEachAssignment(input string, apply func(line int, key, value string) error) error {
+	for idx, line := range strings.Split(input, "\n") {
+		if comment := strings.Index(line, "#"); comment >= 0 {
+			line = line[:comment]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		assignment := strings.SplitN(line, "=", 2)
+		if len(assignment) != 2 {
+			return fmt.Errorf("line %d: expected key=value, found %q", idx+1, line)
+		}
+		if err := apply(idx+1, strings.TrimSpace(assignment[0]), strings.TrimSpace(assignment[1])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPatch returns a patched copy of the program, the original is left untouched.
+func applyPatch(intCode []int, patch []PatchEntry) ([]int, error) {
+	patched := make([]int, len(intCode))
+	copy(patched, intCode)
+	for _, entry := range patch {
+		if entry.address < 0 || entry.address >= len(patched) {
+			return nil, fmt.Errorf("patch address %d out of program range", entry.address)
+		}
+		patched[entry.address] = entry.value
+	}
+	return patched, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldParseSymbols(t *testing.T) {
+	// given
+	input := "# puzzle parameters\nnoun=1\n verb = 2 # second\n\n"
+
+	// when
+	symbols, err := parseSymbols(input)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"noun": 1, "verb": 2}, symbols)
+}
+
+func TestShouldParsePatchWithAddressesAndLabels(t *testing.T) {
+	// given
+	input := "noun=12\n2=-2\n"
+	symbols := map[string]int{"noun": 1}
+
+	// when
+	patch, err := parsePatch(input, symbols)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []PatchEntry{{1, 12}, {2, -2}}, patch)
+}
+
+func TestShouldRejectInvalidPatch(t *testing.T) {
+	// when
+	_, err1 := parsePatch("1=12\nverb=2", map[string]int{})
+	_, err2 := parsePatch("1:12", nil)
+	_, err3 := parsePatch("1=twelve", nil)
+	_, err4 := parseSymbols("noun=-1")
+
+	// then
+	assert.EqualError(t, err1, "line 2: unknown symbol \"verb\"")
+	assert.EqualError(t, err2, "line 1: expected key=value, found \"1:12\"")
+	assert.EqualError(t, err3, "line 1: invalid value \"twelve\"")
+	assert.EqualError(t, err4, "line 1: invalid address \"-1\" for symbol \"noun\"")
+}
+
+func TestShouldApplyPatchToCopyOfProgram(t *testing.T) {
+	// given
+	intCode := []int{1, 0, 0, 3, 99}
+
+	// when
+	patched, err1 := applyPatch(intCode, []PatchEntry{{1, 12}, {2, 2}})
+	_, err2 := applyPatch(intCode, []PatchEntry{{5, 1}})
+
+	// then
+	assert.Nil(t, err1)
+	assert.Equal(t, []int{1, 12, 2, 3, 99}, patched)
+	assert.Equal(t, []int{1, 0, 0, 3, 99}, intCode)
+	assert.EqualError(t, err2, "patch address 5 out of program range")
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxRunRequestSize    = 16 << 20
+	maxStreamRequestSize = 256 << 20
+	maxStreamLineSize    = 16 << 20
+)
+
+// serverLimits caps every execution, requests can only ask for tighter limits.
+var serverLimits = RunLimits{MaxInstructions: 10000000, MaxMemory: 1 << 20, TimeoutMs: 10000}
+
+type RunLimits struct {
+	MaxInstructions int `json:"maxInstructions"`
+	MaxMemory       int `json:"maxMemory"`
+	TimeoutMs       int `json:"timeoutMs"`
+}
+
+// RunRequest describes a program to execute. Patch maps addresses to the values written before the run starts, like
+// the noun and verb written into addresses 1 and 2.
+type RunRequest struct {
+	Program []int          `json:"program"`
+	Inputs  []int          `json:"inputs"`
+	Patch   map[string]int `json:"patch"`
+	Limits  RunLimits      `json:"limits"`
+}
+
+type RunResponse struct {
+	Outputs      []int  `json:"outputs"`
+	Memory       []int  `json:"memory"`
+	Instructions int    `json:"instructions"`
+	Error        string `json:"error,omitempty"`
+}
+
+// StreamEvent is a single line of the /stream response. Outputs are sent as soon as they are produced, the last line
+// carries the final state.
+type StreamEvent struct {
+	Output *int         `json:"output,omitempty"`
+	Result *RunResponse `json:"result,omitempty"`
+}
+
+func newIntCodeServer() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/run", handleRun)
+	mux.HandleFunc("/stream", handleStream)
+	return mux
+}
+
+// handleRun executes a program with all of its inputs given upfront and answers with a single RunResponse.
+func handleRun(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method))
+		return
+	}
+	var request RunRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRunRequestSize)).Decode(&request); err != nil {
+		writeJSONError(w, requestErrorStatus(err), err)
+		return
+	}
+	machine, err := newMachineFromRequest(request)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	inputs := request.Inputs
+	machine.readInput = func() (int, error) {
+		if len(inputs) == 0 {
+			return 0, ErrNoInput
+		}
+		value := inputs[0]
+		inputs = inputs[1:]
+		return value, nil
+	}
+	outputs := []int{}
+	machine.writeOutput = func(value int) error {
+		outputs = append(outputs, value)
+		return nil
+	}
+	ctx, cancel := withRequestTimeout(r.Context(), request.Limits)
+	defer cancel()
+	response := runRequestedMachine(ctx, machine)
+	response.Outputs = outputs
+	writeJSON(w, http.StatusOK, response)
+}
+
+// handleStream serves interactive programs. The first line of the body is a RunRequest, every following line is a
+// single input value read only when the program asks for it. Each output is flushed as its own StreamEvent line.
+func handleStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %v not allowed", r.Method))
+		return
+	}
+	scanner := bufio.NewScanner(http.MaxBytesReader(w, r.Body, maxStreamRequestSize))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxStreamLineSize)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			writeJSONError(w, requestErrorStatus(err), err)
+			return
+		}
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf("missing run request line"))
+		return
+	}
+	var request RunRequest
+	if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	machine, err := newMachineFromRequest(request)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+	// without full duplex, HTTP/1.1 drops the unread inputs as soon as the first output is flushed, HTTP/2 always
+	// supports it
+	if err := http.NewResponseController(w).EnableFullDuplex(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		writeJSONError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	ctx, cancel := withRequestTimeout(r.Context(), request.Limits)
+	defer cancel()
+	// the body is read in the background, so a client that stops sending inputs cannot outlive the timeout
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	machine.readInput = func() (int, error) {
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					if err := scanner.Err(); err != nil {
+						return 0, err
+					}
+					return 0, ErrNoInput
+				}
+				if line = strings.TrimSpace(line); line != "" {
+					return strconv.Atoi(line)
+				}
+			case <-ctx.Done():
+				return 0, machine.checkContext(ctx)
+			}
+		}
+	}
+	outputs := []int{}
+	machine.writeOutput = func(value int) error {
+		outputs = append(outputs, value)
+		if err := encoder.Encode(StreamEvent{Output: &value}); err != nil {
+			return err
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	}
+	response := runRequestedMachine(ctx, machine)
+	response.Outputs = outputs
+	_ = encoder.Encode(StreamEvent{Result: &response})
+}
+
+func newMachineFromRequest(request RunRequest) (*IntCodeMachine, error) {
+	if len(request.Program) == 0 {
+		return nil, fmt.Errorf("empty program")
+	}
+	patch := make([]PatchEntry, 0, len(request.Patch))
+	for key, value := range request.Patch {
+		address, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("patch address %q is not a number", key)
+		}
+		patch = append(patch, PatchEntry{address, value})
+	}
+	sort.Slice(patch, func(i, j int) bool {
+		return patch[i].address < patch[j].address
+	})
+	intCode, err := applyPatch(request.Program, patch)
+	if err != nil {
+		return nil, err
+	}
+	limits := Limits{
+		maxInstructions: tighterLimit(request.Limits.MaxInstructions, serverLimits.MaxInstructions),
+		maxMemory:       tighterLimit(request.Limits.MaxMemory, serverLimits.MaxMemory),
+	}
+	return newIntCodeMachine(intCode, limits), nil
+}
+
+// withRequestTimeout bounds the whole request, waiting for inputs included, by the tighter of both timeouts.
+func withRequestTimeout(ctx context.Context, requestLimits RunLimits) (context.Context, context.CancelFunc) {
+	timeout := tighterLimit(requestLimits.TimeoutMs, serverLimits.TimeoutMs)
+	return context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
+}
+
+func runRequestedMachine(ctx context.Context, machine *IntCodeMachine) RunResponse {
+	response := RunResponse{}
+	if err := machine.run(ctx); err != nil {
+		response.Error = err.Error()
+	}
+	response.Memory = machine.memory
+	response.Instructions = machine.instructionCount
+	return response
+}
+
+// tighterLimit returns the requested limit unless it is missing or looser than the server one.
+func tighterLimit(requested, server int) int {
+	if requested <= 0 || requested > server {
+		return server
+	}
+	return requested
+}
+
+// requestErrorStatus tells a body over its size limit apart from a malformed one.
+func requestErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
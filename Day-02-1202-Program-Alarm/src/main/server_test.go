@@ -0,0 +1,219 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShouldRunProgramWithPatchOverHttp(t *testing.T) {
+	// given
+	server := httptest.NewServer(newIntCodeServer())
+	defer server.Close()
+	body := `{"program":[1,0,0,3,2,3,11,0,99,30,40,50],"patch":{"1":9,"2":10}}`
+
+	// when
+	response, err := http.Post(server.URL+"/run", "application/json", strings.NewReader(body))
+
+	// then
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	var result RunResponse
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+	assert.Equal(t, RunResponse{
+		Outputs:      []int{},
+		Memory:       []int{3500, 9, 10, 70, 2, 3, 11, 0, 99, 30, 40, 50},
+		Instructions: 3,
+	}, result)
+}
+
+func TestShouldRunProgramWithInputsOverHttp(t *testing.T) {
+	// given
+	server := httptest.NewServer(newIntCodeServer())
+	defer server.Close()
+	body := `{"program":[3,9,1,9,9,10,4,10,99,0,0],"inputs":[21]}`
+
+	// when
+	response, err := http.Post(server.URL+"/run", "application/json", strings.NewReader(body))
+
+	// then
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	var result RunResponse
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+	assert.Equal(t, []int{42}, result.Outputs)
+	assert.Equal(t, 4, result.Instructions)
+	assert.Empty(t, result.Error)
+}
+
+func TestShouldReportLimitErrorsOverHttp(t *testing.T) {
+	// given
+	server := httptest.NewServer(newIntCodeServer())
+	defer server.Close()
+	body := `{"program":[1,0,0,0,1,0,0,0,99],"limits":{"maxInstructions":1}}`
+
+	// when
+	response, err := http.Post(server.URL+"/run", "application/json", strings.NewReader(body))
+
+	// then
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	var result RunResponse
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&result))
+	assert.Equal(t, 1, result.Instructions)
+	assert.Equal(t, "instruction limit exceeded: 1", result.Error)
+}
+
+func TestShouldRejectInvalidRunRequests(t *testing.T) {
+	// given
+	server := httptest.NewServer(newIntCodeServer())
+	defer server.Close()
+
+	// when
+	response1, err1 := http.Post(server.URL+"/run", "application/json", strings.NewReader(`{"program":`))
+	response2, err2 := http.Post(server.URL+"/run", "application/json",
+		strings.NewReader(`{"program":[99],"patch":{"5":1}}`))
+	response3, err3 := http.Get(server.URL + "/run")
+
+	// then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	defer response1.Body.Close()
+	defer response2.Body.Close()
+	defer response3.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, response1.StatusCode)
+	assert.Equal(t, http.StatusBadRequest, response2.StatusCode)
+	assert.Equal(t, http.StatusMethodNotAllowed, response3.StatusCode)
+}
+
+func TestShouldStreamOutputsOfInteractiveProgram(t *testing.T) {
+	// given
+	server := httptest.NewServer(newIntCodeServer())
+	defer server.Close()
+	// echoes two inputs back
+	body := "{\"program\":[3,9,4,9,3,9,4,9,99,0]}\n5\n\n7\n"
+
+	// when
+	response, err := http.Post(server.URL+"/stream", "application/x-ndjson", strings.NewReader(body))
+
+	// then
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	events, err := readStreamEvents(response.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, 5, *events[0].Output)
+	assert.Equal(t, 7, *events[1].Output)
+	assert.Equal(t, []int{5, 7}, events[2].Result.Outputs)
+	assert.Equal(t, 5, events[2].Result.Instructions)
+	assert.Empty(t, events[2].Result.Error)
+}
+
+func TestShouldReadEveryInputOnlyAfterPreviousOutput(t *testing.T) {
+	// given
+	server := httptest.NewServer(newIntCodeServer())
+	defer server.Close()
+	bodyReader, bodyWriter := io.Pipe()
+	// a server waiting for the whole body would block the client forever
+	timeout := time.AfterFunc(5*time.Second, func() {
+		_ = bodyWriter.CloseWithError(errors.New("timed out waiting for output"))
+	})
+	defer timeout.Stop()
+	firstOutputRead := make(chan struct{})
+	go func() {
+		_, _ = io.WriteString(bodyWriter, "{\"program\":[3,9,4,9,3,9,4,9,99,0]}\n5\n")
+		<-firstOutputRead
+		_, _ = io.WriteString(bodyWriter, "7\n")
+		_ = bodyWriter.Close()
+	}()
+
+	// when
+	response, err := http.Post(server.URL+"/stream", "application/x-ndjson", bodyReader)
+
+	// then
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	decoder := json.NewDecoder(response.Body)
+	var first StreamEvent
+	assert.Nil(t, decoder.Decode(&first))
+	assert.Equal(t, 5, *first.Output)
+	close(firstOutputRead)
+	events, err := readStreamEvents(io.MultiReader(decoder.Buffered(), response.Body))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.Equal(t, 7, *events[0].Output)
+	assert.Equal(t, []int{5, 7}, events[1].Result.Outputs)
+	assert.Empty(t, events[1].Result.Error)
+}
+
+func TestShouldTimeOutWaitingForStreamedInput(t *testing.T) {
+	// given
+	server := httptest.NewServer(newIntCodeServer())
+	defer server.Close()
+	bodyReader, bodyWriter := io.Pipe()
+	defer bodyWriter.Close()
+	// the client sends the run request and then goes quiet
+	go func() {
+		_, _ = io.WriteString(bodyWriter, "{\"program\":[3,5,4,5,99,0],\"limits\":{\"timeoutMs\":100}}\n")
+	}()
+	client := http.Client{Timeout: 5 * time.Second}
+
+	// when
+	response, err := client.Post(server.URL+"/stream", "application/x-ndjson", bodyReader)
+
+	// then
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	events, err := readStreamEvents(response.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, "execution timed out after 0 instructions", events[0].Result.Error)
+}
+
+func TestShouldRejectTooLargeRunRequest(t *testing.T) {
+	// given
+	server := httptest.NewServer(newIntCodeServer())
+	defer server.Close()
+	body := "{\"program\":[" + strings.Repeat("0,", maxRunRequestSize/2) + "99]}"
+
+	// when
+	response, err := http.Post(server.URL+"/run", "application/json", strings.NewReader(body))
+
+	// then
+	assert.Nil(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, response.StatusCode)
+}
+
+func TestShouldUseTighterLimit(t *testing.T) {
+	// when
+	result1 := tighterLimit(0, 100)
+	result2 := tighterLimit(50, 100)
+	result3 := tighterLimit(500, 100)
+
+	// then
+	assert.Equal(t, 100, result1)
+	assert.Equal(t, 50, result2)
+	assert.Equal(t, 100, result3)
+}
+
+func readStreamEvents(body io.Reader) ([]StreamEvent, error) {
+	var events []StreamEvent
+	decoder := json.NewDecoder(body)
+	for decoder.More() {
+		var event StreamEvent
+		if err := decoder.Decode(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const inputEvent = "input"
+const outputEvent = "output"
+
+var errSessionDiverged = errors.New("session diverged")
+
+// SessionEvent is a single value consumed or produced by the program, together with the number of instructions
+// executed before it happened.
+type SessionEvent struct {
+	kind        string
+	instruction int
+	value       int
+}
+
+// SessionDivergence describes the first event where a replay differs from the recording. A nil expected event means
+// the program did more than was recorded, a nil actual event means it stopped before reaching the recorded event.
+type SessionDivergence struct {
+	index            int
+	expected, actual *SessionEvent
+}
+
+type SessionRecorder struct {
+	events []SessionEvent
+}
+
+// recordSession wraps the machine input and output so that every value passing through them is logged.
+func recordSession(machine *IntCodeMachine) *SessionRecorder {
+	recorder := &SessionRecorder{}
+	readInput := machine.readInput
+	writeOutput := machine.writeOutput
+	machine.readInput = func() (int, error) {
+		if readInput == nil {
+			return 0, ErrNoInput
+		}
+		value, err := readInput()
+		if err != nil {
+			return 0, err
+		}
+		recorder.events = append(recorder.events, SessionEvent{inputEvent, machine.instructionCount, value})
+		return value, nil
+	}
+	machine.writeOutput = func(value int) error {
+		recorder.events = append(recorder.events, SessionEvent{outputEvent, machine.instructionCount, value})
+		if writeOutput == nil {
+			return nil
+		}
+		return writeOutput(value)
+	}
+	return recorder
+}
+
+func (recorder *SessionRecorder) save(path string) error {
+	return ioutil.WriteFile(path, []byte(formatSession(recorder.events)), 0644)
+}
+
+func formatSession(events []SessionEvent) string {
+	var builder strings.Builder
+	for _, event := range events {
+		builder.WriteString(event.String())
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+func loadSession(path string) ([]SessionEvent, error) {
+	content, err := getInput(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseSession(content)
+}
+
+// parseSession reads events written by formatSession, one "<kind> <instruction> <value>" per line.
+func parseSession(input string) ([]SessionEvent, error) {
+	var events []SessionEvent
+	for idx, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 || fields[0] != inputEvent && fields[0] != outputEvent {
+			return nil, fmt.Errorf("line %d: unexpected session event %q", idx+1, line)
+		}
+		instruction, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", idx+1, err)
+		}
+		value, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", idx+1, err)
+		}
+		events = append(events, SessionEvent{fields[0], instruction, value})
+	}
+	return events, nil
+}
+
+// replaySession runs the program feeding it the recorded inputs and compares every event with the recording. It
+// returns the first divergence, or nil when the run reproduces the session exactly.
+func replaySession(ctx context.Context, intCode []int, events []SessionEvent, limits Limits) (*SessionDivergence, error) {
+	machine := newIntCodeMachine(intCode, limits)
+	var divergence *SessionDivergence
+	next := 0
+	check := func(actual SessionEvent) error {
+		if next >= len(events) {
+			divergence = &SessionDivergence{next, nil, &actual}
+			return errSessionDiverged
+		}
+		expected := events[next]
+		if expected.kind != actual.kind || expected.instruction != actual.instruction ||
+			actual.kind == outputEvent && expected.value != actual.value {
+			divergence = &SessionDivergence{next, &expected, &actual}
+			return errSessionDiverged
+		}
+		next++
+		return nil
+	}
+	machine.readInput = func() (int, error) {
+		value := 0
+		if next < len(events) {
+			value = events[next].value
+		}
+		if err := check(SessionEvent{inputEvent, machine.instructionCount, value}); err != nil {
+			return 0, err
+		}
+		return value, nil
+	}
+	machine.writeOutput = func(value int) error {
+		return check(SessionEvent{outputEvent, machine.instructionCount, value})
+	}
+	err := machine.run(ctx)
+	if divergence != nil {
+		return divergence, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if next < len(events) {
+		return &SessionDivergence{next, &events[next], nil}, nil
+	}
+	return nil, nil
+}
+
+func (event SessionEvent) String() string {
+	return fmt.Sprintf("%s %d %d", event.kind, event.instruction, event.value)
+}
+
+func (divergence SessionDivergence) String() string {
+	switch {
+	case divergence.expected == nil:
+		return fmt.Sprintf("event %d: unexpected %v", divergence.index, *divergence.actual)
+	case divergence.actual == nil:
+		return fmt.Sprintf("event %d: expected %v, program halted", divergence.index, *divergence.expected)
+	}
+	return fmt.Sprintf("event %d: expected %v, got %v", divergence.index, *divergence.expected, *divergence.actual)
+}
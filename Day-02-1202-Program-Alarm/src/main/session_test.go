@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+)
+
+// doubler reads a value, adds it to itself and outputs the result
+var doubler = []int{3, 9, 1, 9, 9, 10, 4, 10, 99, 0, 0}
+
+func TestShouldRecordSessionEvents(t *testing.T) {
+	// given
+	machine := newIntCodeMachine(copyIntCode(doubler), Limits{})
+	machine.readInput = func() (int, error) { return 21, nil }
+	recorder := recordSession(machine)
+
+	// when
+	err := machine.run(context.Background())
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, []SessionEvent{{inputEvent, 0, 21}, {outputEvent, 2, 42}}, recorder.events)
+}
+
+func TestShouldReplaySessionWithoutDivergence(t *testing.T) {
+	// given
+	events := []SessionEvent{{inputEvent, 0, 21}, {outputEvent, 2, 42}}
+
+	// when
+	divergence, err := replaySession(context.Background(), copyIntCode(doubler), events, Limits{})
+
+	// then
+	assert.Nil(t, err)
+	assert.Nil(t, divergence)
+}
+
+func TestShouldReportFirstSessionDivergence(t *testing.T) {
+	// given
+	events := []SessionEvent{{inputEvent, 0, 21}, {outputEvent, 2, 42}}
+	squarer := copyIntCode(doubler)
+	squarer[2] = 2
+
+	// when
+	divergence1, err1 := replaySession(context.Background(), squarer, events, Limits{})
+	divergence2, err2 := replaySession(context.Background(), copyIntCode(doubler), events[:1], Limits{})
+	divergence3, err3 := replaySession(context.Background(), copyIntCode(doubler),
+		append(events, SessionEvent{outputEvent, 3, 0}), Limits{})
+
+	// then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, &SessionDivergence{1, &events[1], &SessionEvent{outputEvent, 2, 441}}, divergence1)
+	assert.Equal(t, &SessionDivergence{1, nil, &SessionEvent{outputEvent, 2, 42}}, divergence2)
+	assert.Equal(t, "event 2: expected output 3 0, program halted", divergence3.String())
+}
+
+func TestShouldSaveAndLoadSession(t *testing.T) {
+	// given
+	recorder := &SessionRecorder{[]SessionEvent{{inputEvent, 0, -7}, {outputEvent, 5, 12}}}
+	tmpfile, err := ioutil.TempFile("", "session")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.Remove(tmpfile.Name())
+	if err := tmpfile.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	// when
+	saveErr := recorder.save(tmpfile.Name())
+	events, loadErr := loadSession(tmpfile.Name())
+
+	// then
+	assert.Nil(t, saveErr)
+	assert.Nil(t, loadErr)
+	assert.Equal(t, recorder.events, events)
+}
+
+func TestShouldRejectMalformedSession(t *testing.T) {
+	// given
+	input := "input 0 5\njump 1 2\n"
+
+	// when
+	_, err := parseSession(input)
+
+	// then
+	assert.EqualError(t, err, "line 2: unexpected session event \"jump 1 2\"")
+}
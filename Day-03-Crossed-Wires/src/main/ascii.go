@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrGridTooLarge = errors.New("grid too large")
+
+type asciiCell struct {
+	wires                        map[int]bool
+	horizontal, vertical, corner bool
+}
+
+// renderASCII draws the wires like the puzzle statement does: `o` for the central port, `-` and `|` for wire runs,
+// `+` for corners and `X` where different wires meet. The grid is cropped to the wires with a margin of one `.`, and
+// grids wider than maxWidth or higher than maxHeight are refused.
+func renderASCII(wireCoords [][]Point, maxWidth, maxHeight int) (string, error) {
+	bounds := computeBounds(wireCoords)
+	width, height := bounds.maxX-bounds.minX+3, bounds.maxY-bounds.minY+3
+	if width > maxWidth || height > maxHeight {
+		return "", fmt.Errorf("%w: %dx%d exceeds %dx%d", ErrGridTooLarge, width, height, maxWidth, maxHeight)
+	}
+	cells := make([][]asciiCell, height)
+	for row := range cells {
+		cells[row] = make([]asciiCell, width)
+	}
+	cellAt := func(point Point) *asciiCell {
+		return &cells[bounds.maxY+1-point.y][point.x-bounds.minX+1]
+	}
+	for wire, coords := range wireCoords {
+		for i := 0; i < len(coords)-1; i++ {
+			segment := Segment{coords[i], coords[i+1]}
+			if segment.p1 == segment.p2 {
+				continue
+			}
+			for _, point := range latticePoints(segment) {
+				cell := cellAt(point)
+				if cell.wires == nil {
+					cell.wires = make(map[int]bool)
+				}
+				cell.wires[wire] = true
+				if isHorizontal(segment) {
+					cell.horizontal = true
+				} else {
+					cell.vertical = true
+				}
+			}
+		}
+		for i := 1; i < len(coords)-1; i++ {
+			before, after := Segment{coords[i-1], coords[i]}, Segment{coords[i], coords[i+1]}
+			if before.p1 != before.p2 && after.p1 != after.p2 && isHorizontal(before) != isHorizontal(after) {
+				cellAt(coords[i]).corner = true
+			}
+		}
+	}
+	var builder strings.Builder
+	for row := range cells {
+		for column, cell := range cells[row] {
+			if row == bounds.maxY+1 && column == 1-bounds.minX {
+				builder.WriteByte('o')
+				continue
+			}
+			builder.WriteByte(cell.symbol())
+		}
+		builder.WriteByte('\n')
+	}
+	return builder.String(), nil
+}
+
+func (cell asciiCell) symbol() byte {
+	switch {
+	case len(cell.wires) > 1:
+		return 'X'
+	case cell.corner || cell.horizontal && cell.vertical:
+		return '+'
+	case cell.horizontal:
+		return '-'
+	case cell.vertical:
+		return '|'
+	}
+	return '.'
+}
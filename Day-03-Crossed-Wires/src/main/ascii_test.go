@@ -0,0 +1,94 @@
+package main
+
+import (
+	"flag"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const goldenPath = "../data/golden"
+const goldenMaxSize = 200
+
+var update = flag.Bool("update", false, "rewrite grid.txt of every golden fixture")
+
+// TestShouldMatchGoldenGrids renders every wires.txt under src/data/golden and compares it with grid.txt next to it.
+// Run `go test ./... -update` to rewrite them.
+func TestShouldMatchGoldenGrids(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join(goldenPath, "*", "wires.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatalf("no golden fixtures found in %v", goldenPath)
+	}
+	for _, input := range inputs {
+		dir := filepath.Dir(input)
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			// given
+			content, err := getInput(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wireInstructions := strings.Fields(content)
+			wireCoords := make([][]Point, len(wireInstructions))
+			for i, instructions := range wireInstructions {
+				wireCoords[i] = mustComputeWireCoords(instructions)
+			}
+
+			// when
+			grid, err := renderASCII(wireCoords, goldenMaxSize, goldenMaxSize)
+
+			// then
+			assert.NoError(t, err)
+			gridPath := filepath.Join(dir, "grid.txt")
+			if *update {
+				if err := ioutil.WriteFile(gridPath, []byte(grid), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			expected, err := getInput(gridPath)
+			if err != nil {
+				t.Fatalf("%v, run with -update to create it", err)
+			}
+			assert.Equal(t, expected, grid)
+		})
+	}
+}
+
+func TestShouldRefuseGridsBeyondLimit(t *testing.T) {
+	// given
+	wireCoords := [][]Point{mustComputeWireCoords("R8,U5,L5,D3"), mustComputeWireCoords("U7,R6,D4,L4")}
+
+	// when
+	_, widthErr := renderASCII(wireCoords, 10, 10)
+	_, heightErr := renderASCII(wireCoords, 11, 9)
+	grid, err := renderASCII(wireCoords, 11, 10)
+
+	// then
+	assert.ErrorIs(t, widthErr, ErrGridTooLarge)
+	assert.EqualError(t, widthErr, "grid too large: 11x10 exceeds 10x10")
+	assert.ErrorIs(t, heightErr, ErrGridTooLarge)
+	assert.NoError(t, err)
+	assert.Len(t, grid, 10*12)
+}
+
+func TestShouldDrawSelfCrossingOfWireAsCorner(t *testing.T) {
+	// given
+	wireCoords := [][]Point{mustComputeWireCoords("R3,U2,L1,D3")}
+
+	// when
+	grid, err := renderASCII(wireCoords, 10, 10)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "......\n"+
+		"...++.\n"+
+		"...||.\n"+
+		".o-++.\n"+
+		"...|..\n"+
+		"......\n", grid)
+}
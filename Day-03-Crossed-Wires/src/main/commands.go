@@ -0,0 +1,283 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// runCommand dispatches the tools available next to the puzzle solution, e.g. `go run ./src/main wires`.
+func runCommand(name string, args []string) error {
+	switch name {
+	case "wires":
+		return wiresCommand(args)
+	case "intersections":
+		return intersectionsCommand(args)
+	case "svg":
+		return svgCommand(args)
+	case "ascii":
+		return asciiCommand(args)
+	case "self":
+		return selfCommand(args)
+	case "closest":
+		return closestCommand(args)
+	}
+	return fmt.Errorf("unknown command %q", name)
+}
+
+// wiresCommand prints the answers for every pair of wires and, with -k, the points crossed by at least k wires,
+// e.g. `wires -k 3 circuits.txt`.
+func wiresCommand(args []string) error {
+	flags := flag.NewFlagSet("wires", flag.ContinueOnError)
+	k := flags.Int("k", 0, "also list the points crossed by at least k wires")
+	options := addIntersectionFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	wireInstructions, err := loadWireInstructionsArgument(flags.Args())
+	if err != nil {
+		return err
+	}
+	pairs, err := computeWirePairs(wireInstructions, *options)
+	if err != nil {
+		return gridOnlyError("wires", err)
+	}
+	for _, pair := range pairs {
+		fmt.Println(pair)
+	}
+	if *k == 0 {
+		return nil
+	}
+	crossings, err := computePointsCrossedByAtLeast(wireInstructions, *k, *options)
+	if err != nil {
+		return gridOnlyError("wires", err)
+	}
+	fmt.Println(fmt.Sprintf("Points crossed by at least %d wires >> %d", *k, len(crossings)))
+	for _, crossing := range crossings {
+		fmt.Println(crossing)
+	}
+	return nil
+}
+
+// intersectionsCommand lists every intersection of the first two wires, e.g. `intersections -sort delay`. Diagonal
+// wires are intersected by computeGeneralIntersections.
+func intersectionsCommand(args []string) error {
+	flags := flag.NewFlagSet("intersections", flag.ContinueOnError)
+	orderName := flags.String("sort", string(ByDistance), "sort by distance, delay, first, second, x or y")
+	options := addIntersectionFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	order, err := parseIntersectionOrder(*orderName)
+	if err != nil {
+		return err
+	}
+	wireInstructions, err := loadWireInstructionsArgument(flags.Args())
+	if err != nil {
+		return err
+	}
+	wireCoords, err := parseWiresCoords(wireInstructions[:2])
+	if err != nil {
+		return err
+	}
+	if !isAxisAlignedWire(wireCoords[0]) || !isAxisAlignedWire(wireCoords[1]) {
+		intersections := computeGeneralIntersections(wireCoords[0], wireCoords[1], *options)
+		sortRationalIntersections(intersections, order)
+		for _, intersection := range intersections {
+			fmt.Println(intersection)
+		}
+		return nil
+	}
+	intersections := computeCoordsIntersections(wireCoords[0], wireCoords[1], *options)
+	sortIntersections(intersections, order)
+	for _, intersection := range intersections {
+		fmt.Println(intersection)
+	}
+	return nil
+}
+
+// svgCommand draws the wires as SVG, e.g. `svg -o wires.svg`.
+func svgCommand(args []string) error {
+	flags := flag.NewFlagSet("svg", flag.ContinueOnError)
+	output := flags.String("o", "", "file to write the SVG to instead of the standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	wireInstructions, err := loadWireInstructionsArgument(flags.Args())
+	if err != nil {
+		return err
+	}
+	wireCoords, err := computeWiresCoords(wireInstructions)
+	if err != nil {
+		return gridOnlyError("svg", err)
+	}
+	svg := renderSVG(wireCoords)
+	if *output == "" {
+		fmt.Print(svg)
+		return nil
+	}
+	return ioutil.WriteFile(*output, []byte(svg), 0644)
+}
+
+// asciiCommand draws small inputs like the puzzle statement does, e.g. `ascii -max-width 80 example.txt`. A single
+// wire is enough.
+func asciiCommand(args []string) error {
+	flags := flag.NewFlagSet("ascii", flag.ContinueOnError)
+	maxWidth := flags.Int("max-width", 200, "widest grid to draw")
+	maxHeight := flags.Int("max-height", 200, "highest grid to draw")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	wireInstructions, err := loadAnyWireInstructionsArgument(flags.Args())
+	if err != nil {
+		return err
+	}
+	wireCoords, err := computeWiresCoords(wireInstructions)
+	if err != nil {
+		return gridOnlyError("ascii", err)
+	}
+	grid, err := renderASCII(wireCoords, *maxWidth, *maxHeight)
+	if err != nil {
+		return err
+	}
+	fmt.Print(grid)
+	return nil
+}
+
+// selfCommand reports how every wire of the file crosses itself, e.g. `self -loops messy.txt`. A single wire is
+// enough.
+func selfCommand(args []string) error {
+	flags := flag.NewFlagSet("self", flag.ContinueOnError)
+	listLoops := flags.Bool("loops", false, "list every loop")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	wireInstructions, err := loadAnyWireInstructionsArgument(flags.Args())
+	if err != nil {
+		return err
+	}
+	wireCoords, err := computeWiresCoords(wireInstructions)
+	if err != nil {
+		return gridOnlyError("self", err)
+	}
+	for i, coords := range wireCoords {
+		self := computeSelfIntersections(coords)
+		fmt.Println(fmt.Sprintf("Wire %d >> %v", i+1, self))
+		if *listLoops {
+			for _, loop := range self.loops {
+				fmt.Println(fmt.Sprintf("  %v", loop))
+			}
+		}
+	}
+	return nil
+}
+
+// closestCommand finds the intersection of the first two wires closest to a point in any metric, e.g.
+// `closest -metric chebyshev -from 10,-3`. Diagonal wires are ranked by rankRationalIntersections.
+func closestCommand(args []string) error {
+	flags := flag.NewFlagSet("closest", flag.ContinueOnError)
+	metricName := flags.String("metric", "manhattan", "manhattan, chebyshev, euclidean or squared-euclidean")
+	from := flags.String("from", "0,0", "reference point as x,y")
+	options := addIntersectionFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	metric, err := parseMetric(*metricName)
+	if err != nil {
+		return err
+	}
+	reference, err := parsePoint(*from)
+	if err != nil {
+		return err
+	}
+	wireInstructions, err := loadWireInstructionsArgument(flags.Args())
+	if err != nil {
+		return err
+	}
+	wireCoords, err := parseWiresCoords(wireInstructions[:2])
+	if err != nil {
+		return err
+	}
+	if !isAxisAlignedWire(wireCoords[0]) || !isAxisAlignedWire(wireCoords[1]) {
+		intersections := computeGeneralIntersections(wireCoords[0], wireCoords[1], *options)
+		if len(intersections) == 0 {
+			return errors.New("the wires do not intersect")
+		}
+		fmt.Println(rankRationalIntersections(intersections, metric, reference)[0])
+		return nil
+	}
+	closest, _, found := computeClosestIntersection(wireCoords[0], wireCoords[1], metric, reference, *options)
+	if !found {
+		return errors.New("the wires do not intersect")
+	}
+	fmt.Println(closest)
+	return nil
+}
+
+// gridOnlyError points to the commands handling diagonal wires when a grid based command is given one.
+func gridOnlyError(command string, err error) error {
+	if errors.Is(err, ErrNotAxisAligned) {
+		return fmt.Errorf("%v; %s only handles horizontal and vertical wires, diagonal ones are supported by "+
+			"intersections, closest and the default run of two wires", err, command)
+	}
+	return err
+}
+
+// addIntersectionFlags adds the -touching and -origin flags shared by the commands looking for intersections.
+func addIntersectionFlags(flags *flag.FlagSet) *IntersectionOptions {
+	options := &IntersectionOptions{}
+	flags.BoolVar(&options.touching, "touching", false, "count wires touching at an end or a corner as intersecting")
+	flags.BoolVar(&options.origin, "origin", false, "count the central port as an intersection")
+	return options
+}
+
+// loadWireInstructionsArgument reads the file given as the only argument, or the puzzle input without one.
+func loadWireInstructionsArgument(args []string) ([]string, error) {
+	inputPath, err := inputPathArgument(args)
+	if err != nil {
+		return nil, err
+	}
+	return loadWireInstructions(inputPath)
+}
+
+// loadAnyWireInstructionsArgument is loadWireInstructionsArgument for commands that are fine with a single wire.
+func loadAnyWireInstructionsArgument(args []string) ([]string, error) {
+	inputPath, err := inputPathArgument(args)
+	if err != nil {
+		return nil, err
+	}
+	input, err := getInput(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	wireInstructions := splitWireInstructions(input)
+	if len(wireInstructions) == 0 {
+		return nil, fmt.Errorf("%v: no wires found", inputPath)
+	}
+	return wireInstructions, nil
+}
+
+func inputPathArgument(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		pwd, _ := os.Getwd()
+		return pwd + path, nil
+	case 1:
+		return args[0], nil
+	}
+	return "", fmt.Errorf("expected at most one input file, found %d", len(args))
+}
+
+func loadWireInstructions(path string) ([]string, error) {
+	input, err := getInput(path)
+	if err != nil {
+		return nil, err
+	}
+	wireInstructions, err := parseWireInstructions(input)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", path, err)
+	}
+	return wireInstructions, nil
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// RationalPoint is an exact point. Segments running in arbitrary directions may cross between grid points, so its
+// coordinates are fractions.
+type RationalPoint struct {
+	x, y *big.Rat
+}
+
+// RationalSegment is the part shared by two segments: from p1 to p2, or a single point when both are equal.
+type RationalSegment struct {
+	p1, p2 RationalPoint
+}
+
+// RationalIntersection is an Intersection of wires running in any direction, which may cross between grid points.
+type RationalIntersection struct {
+	point                             RationalPoint
+	distance, firstSteps, secondSteps *big.Rat
+}
+
+func isAxisAligned(segment Segment) bool {
+	return segment.p1.x == segment.p2.x || segment.p1.y == segment.p2.y
+}
+
+func isAxisAlignedWire(wireCoords []Point) bool {
+	for i := 0; i < len(wireCoords)-1; i++ {
+		if !isAxisAligned(Segment{wireCoords[i], wireCoords[i+1]}) {
+			return false
+		}
+	}
+	return true
+}
+
+// computeGeneralSharedPart intersects segments running in any direction. With p1 + t·r for the first segment and
+// q1 + u·s for the second, crossing segments meet where 0 < t, u < 1, and collinear segments overlap where their
+// ranges of t do. All values are exact, so results do not depend on rounding. Touching follows IntersectionOptions
+// like for horizontal and vertical segments.
+func computeGeneralSharedPart(segment1, segment2 Segment, options IntersectionOptions) *RationalSegment {
+	r := Point{segment1.p2.x - segment1.p1.x, segment1.p2.y - segment1.p1.y}
+	s := Point{segment2.p2.x - segment2.p1.x, segment2.p2.y - segment2.p1.y}
+	if r == (Point{0, 0}) || s == (Point{0, 0}) {
+		return nil
+	}
+	qp := Point{segment2.p1.x - segment1.p1.x, segment2.p1.y - segment1.p1.y}
+	one := big.NewRat(1, 1)
+	inRange := func(value *big.Rat) bool {
+		if options.touching {
+			return value.Sign() >= 0 && value.Cmp(one) <= 0
+		}
+		return value.Sign() > 0 && value.Cmp(one) < 0
+	}
+	if rxs := cross(r, s); rxs.Sign() != 0 {
+		t := new(big.Rat).SetFrac(cross(qp, s), rxs)
+		u := new(big.Rat).SetFrac(cross(qp, r), rxs)
+		if !inRange(t) || !inRange(u) {
+			return nil
+		}
+		point := pointAlong(segment1, t)
+		return &RationalSegment{point, point}
+	}
+	if cross(qp, r).Sign() != 0 {
+		return nil
+	}
+	rr := dot(r, r)
+	t0 := new(big.Rat).SetFrac(dot(qp, r), rr)
+	t1 := new(big.Rat).Add(t0, new(big.Rat).SetFrac(dot(s, r), rr))
+	if t0.Cmp(t1) > 0 {
+		t0, t1 = t1, t0
+	}
+	if t0.Sign() < 0 {
+		t0 = new(big.Rat)
+	}
+	if t1.Cmp(one) > 0 {
+		t1 = one
+	}
+	if comparison := t0.Cmp(t1); comparison > 0 || comparison == 0 && !options.touching {
+		return nil
+	}
+	return &RationalSegment{pointAlong(segment1, t0), pointAlong(segment1, t1)}
+}
+
+// computeGeneralClosestIntersection solves both parts for wires running in any direction. Steps are counted like in
+// part 2, as the Manhattan length of the way along the wire. The central port is skipped unless options say
+// otherwise. Like on the grid, an overlap running through it is then represented by its grid points next to the port,
+// as the points in between get arbitrarily close. Both values are nil when the wires do not intersect.
+func computeGeneralClosestIntersection(firstWireCoords, secondWireCoords []Point,
+	options IntersectionOptions) (distance, delay *big.Rat) {
+	origin := newRationalPoint(Point{0, 0})
+	firstPath := new(big.Rat)
+	for i := 0; i < len(firstWireCoords)-1; i++ {
+		segment1 := Segment{firstWireCoords[i], firstWireCoords[i+1]}
+		secondPath := new(big.Rat)
+		for j := 0; j < len(secondWireCoords)-1; j++ {
+			segment2 := Segment{secondWireCoords[j], secondWireCoords[j+1]}
+			if shared := computeGeneralSharedPart(segment1, segment2, options); shared != nil {
+				points := shared.candidatePoints()
+				if !options.origin {
+					points = append(points, shared.originNeighbours(segment1)...)
+				}
+				for _, point := range points {
+					pointDistance := rationalManhattanDistance(origin, point)
+					if pointDistance.Sign() == 0 && !options.origin {
+						continue
+					}
+					if distance == nil || pointDistance.Cmp(distance) < 0 {
+						distance = pointDistance
+					}
+					pathLength := rationalManhattanDistance(newRationalPoint(segment1.p1), point)
+					pathLength.Add(pathLength, firstPath)
+					pathLength.Add(pathLength, secondPath)
+					pathLength.Add(pathLength, rationalManhattanDistance(newRationalPoint(segment2.p1), point))
+					if delay == nil || pathLength.Cmp(delay) < 0 {
+						delay = pathLength
+					}
+				}
+			}
+			secondPath.Add(secondPath, big.NewRat(int64(manhattanDistance(segment2.p1, segment2.p2)), 1))
+		}
+		firstPath.Add(firstPath, big.NewRat(int64(manhattanDistance(segment1.p1, segment1.p2)), 1))
+	}
+	return distance, delay
+}
+
+// computeGeneralIntersections is computeCoordsIntersections for wires running in any direction. Like on the grid, an
+// overlap contributes each of its grid points, its ends being ends of the wires, and a point reached several times
+// keeps the fewest steps of every wire.
+func computeGeneralIntersections(firstWireCoords, secondWireCoords []Point,
+	options IntersectionOptions) []RationalIntersection {
+	origin := newRationalPoint(Point{0, 0})
+	byPoint := make(map[string]int)
+	var intersections []RationalIntersection
+	firstPath := new(big.Rat)
+	for i := 0; i < len(firstWireCoords)-1; i++ {
+		segment1 := Segment{firstWireCoords[i], firstWireCoords[i+1]}
+		secondPath := new(big.Rat)
+		for j := 0; j < len(secondWireCoords)-1; j++ {
+			segment2 := Segment{secondWireCoords[j], secondWireCoords[j+1]}
+			if shared := computeGeneralSharedPart(segment1, segment2, options); shared != nil {
+				for _, point := range shared.latticePoints() {
+					distance := rationalManhattanDistance(origin, point)
+					if distance.Sign() == 0 && !options.origin {
+						continue
+					}
+					firstSteps := rationalManhattanDistance(newRationalPoint(segment1.p1), point)
+					firstSteps.Add(firstSteps, firstPath)
+					secondSteps := rationalManhattanDistance(newRationalPoint(segment2.p1), point)
+					secondSteps.Add(secondSteps, secondPath)
+					idx, found := byPoint[point.String()]
+					if !found {
+						byPoint[point.String()] = len(intersections)
+						intersections = append(intersections,
+							RationalIntersection{point, distance, firstSteps, secondSteps})
+						continue
+					}
+					if firstSteps.Cmp(intersections[idx].firstSteps) < 0 {
+						intersections[idx].firstSteps = firstSteps
+					}
+					if secondSteps.Cmp(intersections[idx].secondSteps) < 0 {
+						intersections[idx].secondSteps = secondSteps
+					}
+				}
+			}
+			secondPath.Add(secondPath, big.NewRat(int64(manhattanDistance(segment2.p1, segment2.p2)), 1))
+		}
+		firstPath.Add(firstPath, big.NewRat(int64(manhattanDistance(segment1.p1, segment1.p2)), 1))
+	}
+	return intersections
+}
+
+// latticePoints returns the crossing point, or every grid point of an overlap from p1 to p2. The ends of an overlap
+// are ends of the wires, so they are grid points.
+func (shared RationalSegment) latticePoints() []RationalPoint {
+	if shared.isPoint() {
+		return []RationalPoint{shared.p1}
+	}
+	start := Point{int(shared.p1.x.Num().Int64()), int(shared.p1.y.Num().Int64())}
+	end := Point{int(shared.p2.x.Num().Int64()), int(shared.p2.y.Num().Int64())}
+	step, count := gridStep(Point{end.x - start.x, end.y - start.y})
+	points := make([]RationalPoint, count+1)
+	for i := range points {
+		points[i] = newRationalPoint(Point{start.x + i*step.x, start.y + i*step.y})
+	}
+	return points
+}
+
+// gridStep splits a vector into the shortest step between grid points along it and the number of such steps.
+func gridStep(vector Point) (Point, int) {
+	count := int(new(big.Int).GCD(nil, nil, new(big.Int).Abs(big.NewInt(int64(vector.x))),
+		new(big.Int).Abs(big.NewInt(int64(vector.y)))).Int64())
+	return Point{vector.x / count, vector.y / count}, count
+}
+
+// sortRationalIntersections is sortIntersections for intersections between grid points.
+func sortRationalIntersections(intersections []RationalIntersection, order IntersectionOrder) {
+	value := func(intersection RationalIntersection) *big.Rat {
+		switch order {
+		case ByDelay:
+			return intersection.delay()
+		case ByFirstSteps:
+			return intersection.firstSteps
+		case BySecondSteps:
+			return intersection.secondSteps
+		case ByX:
+			return intersection.point.x
+		case ByY:
+			return intersection.point.y
+		}
+		return intersection.distance
+	}
+	sort.Slice(intersections, func(i, j int) bool {
+		first, second := intersections[i], intersections[j]
+		if comparison := value(first).Cmp(value(second)); comparison != 0 {
+			return comparison < 0
+		}
+		return first.point.isBefore(second.point)
+	})
+}
+
+func (intersection RationalIntersection) delay() *big.Rat {
+	return new(big.Rat).Add(intersection.firstSteps, intersection.secondSteps)
+}
+
+func (intersection RationalIntersection) String() string {
+	return fmt.Sprintf("%v distance %s, steps %s + %s = %s", intersection.point, intersection.distance.RatString(),
+		intersection.firstSteps.RatString(), intersection.secondSteps.RatString(), intersection.delay().RatString())
+}
+
+// isBefore orders points by x and then by y.
+func (point RationalPoint) isBefore(other RationalPoint) bool {
+	if comparison := point.x.Cmp(other.x); comparison != 0 {
+		return comparison < 0
+	}
+	return point.y.Cmp(other.y) < 0
+}
+
+// candidatePoints returns the points of the shared part where the distance to the central port or the delay may be
+// lowest: both are linear along a straight piece, except that the distance bends where the piece crosses an axis.
+func (shared RationalSegment) candidatePoints() []RationalPoint {
+	points := []RationalPoint{shared.p1}
+	if shared.isPoint() {
+		return points
+	}
+	points = append(points, shared.p2)
+	dx := new(big.Rat).Sub(shared.p2.x, shared.p1.x)
+	dy := new(big.Rat).Sub(shared.p2.y, shared.p1.y)
+	for _, axis := range []struct{ start, delta *big.Rat }{{shared.p1.x, dx}, {shared.p1.y, dy}} {
+		if axis.delta.Sign() == 0 {
+			continue
+		}
+		t := new(big.Rat).Quo(new(big.Rat).Neg(axis.start), axis.delta)
+		if t.Sign() > 0 && t.Cmp(big.NewRat(1, 1)) < 0 {
+			points = append(points, RationalPoint{
+				new(big.Rat).Add(shared.p1.x, new(big.Rat).Mul(t, dx)),
+				new(big.Rat).Add(shared.p1.y, new(big.Rat).Mul(t, dy)),
+			})
+		}
+	}
+	return points
+}
+
+// originNeighbours returns the grid points of the shared part next to the central port, when the shared part is a
+// stretch of the segment running through the port.
+func (shared RationalSegment) originNeighbours(segment Segment) []RationalPoint {
+	r := Point{segment.p2.x - segment.p1.x, segment.p2.y - segment.p1.y}
+	if shared.isPoint() || cross(segment.p1, r).Sign() != 0 {
+		return nil
+	}
+	step, _ := gridStep(r)
+	var neighbours []RationalPoint
+	for _, neighbour := range []Point{step, {-step.x, -step.y}} {
+		if point := newRationalPoint(neighbour); shared.contains(point) {
+			neighbours = append(neighbours, point)
+		}
+	}
+	return neighbours
+}
+
+func (shared RationalSegment) isPoint() bool {
+	return shared.p1.x.Cmp(shared.p2.x) == 0 && shared.p1.y.Cmp(shared.p2.y) == 0
+}
+
+// contains tells whether a point on the line of the shared part lies between its ends.
+func (shared RationalSegment) contains(point RationalPoint) bool {
+	between := func(value, end1, end2 *big.Rat) bool {
+		if end1.Cmp(end2) > 0 {
+			end1, end2 = end2, end1
+		}
+		return end1.Cmp(value) <= 0 && value.Cmp(end2) <= 0
+	}
+	return between(point.x, shared.p1.x, shared.p2.x) && between(point.y, shared.p1.y, shared.p2.y)
+}
+
+func newRationalPoint(point Point) RationalPoint {
+	return RationalPoint{big.NewRat(int64(point.x), 1), big.NewRat(int64(point.y), 1)}
+}
+
+// pointAlong returns p1 + t·(p2 - p1).
+func pointAlong(segment Segment, t *big.Rat) RationalPoint {
+	x := new(big.Rat).Mul(t, big.NewRat(int64(segment.p2.x-segment.p1.x), 1))
+	y := new(big.Rat).Mul(t, big.NewRat(int64(segment.p2.y-segment.p1.y), 1))
+	return RationalPoint{x.Add(x, big.NewRat(int64(segment.p1.x), 1)), y.Add(y, big.NewRat(int64(segment.p1.y), 1))}
+}
+
+func rationalManhattanDistance(point1, point2 RationalPoint) *big.Rat {
+	x := new(big.Rat).Sub(point1.x, point2.x)
+	y := new(big.Rat).Sub(point1.y, point2.y)
+	return x.Add(x.Abs(x), y.Abs(y))
+}
+
+// cross and dot multiply as big integers, as products of large coordinates do not fit in an int.
+func cross(vector1, vector2 Point) *big.Int {
+	product := new(big.Int).Mul(big.NewInt(int64(vector1.x)), big.NewInt(int64(vector2.y)))
+	return product.Sub(product, new(big.Int).Mul(big.NewInt(int64(vector1.y)), big.NewInt(int64(vector2.x))))
+}
+
+func dot(vector1, vector2 Point) *big.Int {
+	product := new(big.Int).Mul(big.NewInt(int64(vector1.x)), big.NewInt(int64(vector2.x)))
+	return product.Add(product, new(big.Int).Mul(big.NewInt(int64(vector1.y)), big.NewInt(int64(vector2.y))))
+}
+
+func (point RationalPoint) String() string {
+	return fmt.Sprintf("(%s,%s)", point.x.RatString(), point.y.RatString())
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestShouldParseDiagonalAndVectorInstructions(t *testing.T) {
+	// when
+	result, err := parseWireCoords("NE3,V2,-5,SW1,NW2,SE1,R-2")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []Point{{0, 0}, {3, 3}, {5, -2}, {4, -3}, {2, -1}, {3, -2}, {1, -2}}, result)
+}
+
+func TestShouldNotParseWrongInstructions(t *testing.T) {
+	// when
+	_, unknownErr := parseWireCoords("R3,X5")
+	_, missingErr := parseWireCoords("U1,V3")
+	_, vectorErr := parseWireCoords("V3,up")
+	_, valueErr := parseWireCoords("R3,NE")
+
+	// then
+	assert.EqualError(t, unknownErr, "instruction 2: unknown direction \"X\"")
+	assert.EqualError(t, missingErr, "instruction 2: vector \"V3\" misses its y value")
+	assert.EqualError(t, vectorErr, "instruction 1: invalid vector y value \"up\"")
+	assert.EqualError(t, valueErr, "instruction 2: invalid instruction \"NE\"")
+}
+
+func TestShouldCountInstructionsRatherThanVectorFields(t *testing.T) {
+	// when
+	_, err := parseWireCoords("V1,2,X5")
+
+	// then
+	assert.EqualError(t, err, "instruction 2: unknown direction \"X\"")
+}
+
+func TestShouldRejectDiagonalWiresOnGrid(t *testing.T) {
+	// when
+	axisCoords, axisErr := computeWireCoords("U7,V6,0")
+	_, diagonalErr := computeWireCoords("U7,NE2")
+	_, wiresErr := computeWiresCoords([]string{"R8,U5", "V2,0,U7,NE2"})
+	_, wrongErr := computeWiresCoords([]string{"R8,U5", "U7,Q2"})
+	_, intersectionsErr := computeIntersections("R8,U5", "NE3", IntersectionOptions{})
+	_, closestErr := computeClosestPair("SW2", "R8,U5", IntersectionOptions{})
+
+	// then
+	assert.NoError(t, axisErr)
+	assert.Equal(t, []Point{{0, 0}, {0, 7}, {6, 7}}, axisCoords)
+	assert.EqualError(t, diagonalErr, "instruction 2: only horizontal and vertical segments are supported here")
+	assert.EqualError(t, wiresErr, "wire 2: instruction 3: only horizontal and vertical segments are supported here")
+	assert.EqualError(t, wrongErr, "wire 2: instruction 2: unknown direction \"Q\"")
+	assert.EqualError(t, intersectionsErr,
+		"wire 2: instruction 1: only horizontal and vertical segments are supported here")
+	assert.EqualError(t, closestErr, "wire 1: instruction 1: only horizontal and vertical segments are supported here")
+}
+
+func TestShouldCrossDiagonalsBetweenGridPoints(t *testing.T) {
+	// given
+	segment1 := Segment{Point{0, 0}, Point{4, 4}}
+	segment2 := Segment{Point{0, 3}, Point{3, 0}}
+
+	// when
+	result := computeGeneralSharedPart(segment1, segment2, IntersectionOptions{})
+
+	// then
+	assert.NotNil(t, result)
+	assert.Equal(t, "(3/2,3/2)", result.p1.String())
+	assert.Equal(t, "(3/2,3/2)", result.p2.String())
+}
+
+func TestShouldComputeOverlapOfCollinearDiagonals(t *testing.T) {
+	// given
+	segment1 := Segment{Point{0, 0}, Point{4, 4}}
+	segment2 := Segment{Point{6, 6}, Point{2, 2}}
+
+	// when
+	result := computeGeneralSharedPart(segment1, segment2, IntersectionOptions{})
+
+	// then
+	assert.NotNil(t, result)
+	assert.Equal(t, "(2,2)", result.p1.String())
+	assert.Equal(t, "(4,4)", result.p2.String())
+}
+
+func TestShouldCountTouchingDiagonalsOnlyWhenAsked(t *testing.T) {
+	// given
+	segment1 := Segment{Point{0, 0}, Point{4, 4}}
+	endToEnd := Segment{Point{4, 4}, Point{6, 6}}
+	corner := Segment{Point{4, 4}, Point{8, 0}}
+	parallel := Segment{Point{1, 0}, Point{5, 4}}
+
+	for _, segment2 := range []Segment{endToEnd, corner} {
+		// when
+		strict := computeGeneralSharedPart(segment1, segment2, IntersectionOptions{})
+		touching := computeGeneralSharedPart(segment1, segment2, IntersectionOptions{touching: true})
+
+		// then
+		assert.Nil(t, strict)
+		assert.NotNil(t, touching)
+		assert.Equal(t, "(4,4)", touching.p1.String())
+	}
+	assert.Nil(t, computeGeneralSharedPart(segment1, parallel, IntersectionOptions{touching: true}))
+}
+
+func TestShouldShareSamePartAsAxisAlignedSegments(t *testing.T) {
+	// given
+	random := rand.New(rand.NewSource(3))
+	randomSegment := func() Segment {
+		start := Point{random.Intn(11) - 5, random.Intn(11) - 5}
+		end := start
+		if random.Intn(2) == 0 {
+			end.x = random.Intn(11) - 5
+		} else {
+			end.y = random.Intn(11) - 5
+		}
+		return Segment{start, end}
+	}
+
+	for i := 0; i < 2000; i++ {
+		segment1, segment2 := randomSegment(), randomSegment()
+		options := IntersectionOptions{touching: random.Intn(2) == 0}
+		if segment1.p1 == segment1.p2 || segment2.p1 == segment2.p2 {
+			continue
+		}
+
+		// when
+		general := computeGeneralSharedPart(segment1, segment2, options)
+		axis := computeSegmentsSharedPart(segment1, segment2, options)
+
+		// then
+		if axis == nil {
+			assert.Nil(t, general)
+			continue
+		}
+		assert.NotNil(t, general)
+		if general == nil {
+			continue
+		}
+		expected := []string{newRationalPoint(axis.p1).String(), newRationalPoint(axis.p2).String()}
+		assert.ElementsMatch(t, expected, []string{general.p1.String(), general.p2.String()})
+	}
+}
+
+func TestShouldSolveBothPartsForDiagonalWires(t *testing.T) {
+	// given
+	firstWireCoords, _ := parseWireCoords("NE4")
+	secondWireCoords, _ := parseWireCoords("U3,SE3")
+
+	// when
+	distance, delay := computeGeneralClosestIntersection(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// then
+	assert.Equal(t, big.NewRat(3, 1), distance)
+	assert.Equal(t, big.NewRat(9, 1), delay)
+}
+
+func TestShouldFindClosestPointWhereOverlapCrossesAxis(t *testing.T) {
+	// given
+	firstWireCoords, _ := parseWireCoords("V-5,-1,NE6")
+	secondWireCoords, _ := parseWireCoords("V2,6,SW8")
+
+	// when
+	distance, delay := computeGeneralClosestIntersection(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// then
+	assert.Equal(t, "4", distance.RatString())
+	assert.Equal(t, "28", delay.RatString())
+}
+
+func TestShouldKeepGridPointsNextToCentralPortOnOverlap(t *testing.T) {
+	// given
+	firstWireCoords, _ := parseWireCoords("L3,R6,NE1")
+	secondWireCoords, _ := parseWireCoords("R2")
+
+	// when
+	distance, delay := computeGeneralClosestIntersection(firstWireCoords, secondWireCoords, IntersectionOptions{})
+	expected, err := computeClosestPair("L3,R6", "R2", IntersectionOptions{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, Pair{1, 8}, expected)
+	assert.Equal(t, "1", distance.RatString())
+	assert.Equal(t, "8", delay.RatString())
+}
+
+func TestShouldSolveWiresWithCoordinatesBeyondProductRange(t *testing.T) {
+	// given
+	firstWireCoords, _ := parseWireCoords("NE5000000000")
+	secondWireCoords, _ := parseWireCoords("R8000000000,NW8000000000")
+
+	// when
+	distance, delay := computeGeneralClosestIntersection(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// then
+	assert.Equal(t, "8000000000", distance.RatString())
+	assert.Equal(t, "24000000000", delay.RatString())
+}
+
+func TestShouldListIntersectionsOfDiagonalWires(t *testing.T) {
+	// given
+	firstWireCoords, _ := parseWireCoords("NE4")
+	secondWireCoords, _ := parseWireCoords("U3,SE3,U5,L2")
+
+	// when
+	intersections := computeGeneralIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+	sortRationalIntersections(intersections, ByDelay)
+
+	// then
+	result := make([]string, len(intersections))
+	for i, intersection := range intersections {
+		result[i] = intersection.String()
+	}
+	assert.Equal(t, []string{
+		"(3/2,3/2) distance 3, steps 3 + 6 = 9",
+		"(3,3) distance 6, steps 6 + 12 = 18",
+	}, result)
+}
+
+func TestShouldListEveryGridPointOfDiagonalOverlap(t *testing.T) {
+	// given
+	firstWireCoords, _ := parseWireCoords("NE4")
+	secondWireCoords, _ := parseWireCoords("R6,U6,SW4")
+
+	// when
+	intersections := computeGeneralIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+	sortRationalIntersections(intersections, ByX)
+
+	// then
+	result := make([]string, len(intersections))
+	for i, intersection := range intersections {
+		result[i] = intersection.String()
+	}
+	assert.Equal(t, []string{
+		"(2,2) distance 4, steps 4 + 20 = 24",
+		"(3,3) distance 6, steps 6 + 18 = 24",
+		"(4,4) distance 8, steps 8 + 16 = 24",
+	}, result)
+}
+
+func TestShouldListSameIntersectionsAsGridForAxisAlignedWires(t *testing.T) {
+	// given
+	random := rand.New(rand.NewSource(5))
+
+	for i := 0; i < 100; i++ {
+		firstWireCoords := mustComputeWireCoords(randomWireInstructions(random, 10, 6))
+		secondWireCoords := mustComputeWireCoords(randomWireInstructions(random, 10, 6))
+		options := IntersectionOptions{touching: random.Intn(2) == 0, origin: random.Intn(2) == 0}
+
+		// when
+		general := computeGeneralIntersections(firstWireCoords, secondWireCoords, options)
+
+		// then
+		var expected, result []string
+		for _, intersection := range computeCoordsIntersections(firstWireCoords, secondWireCoords, options) {
+			expected = append(expected, intersection.String())
+		}
+		for _, intersection := range general {
+			result = append(result, intersection.String())
+		}
+		assert.ElementsMatch(t, expected, result)
+	}
+}
+
+func TestShouldReportNoIntersectionOfSeparateDiagonalWires(t *testing.T) {
+	// given
+	firstWireCoords, _ := parseWireCoords("NE4")
+	secondWireCoords, _ := parseWireCoords("SW4")
+
+	// when
+	distance, delay := computeGeneralClosestIntersection(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// then
+	assert.Nil(t, distance)
+	assert.Nil(t, delay)
+}
+
+func TestShouldMatchBothPartsOnAxisAlignedWires(t *testing.T) {
+	// given
+	wires := [][]string{
+		{"R8,U5,L5,D3", "U7,R6,D4,L4"},
+		{"R75,D30,R83,U83,L12,D49,R71,U7,L72", "U62,R66,U55,R34,D71,R55,D58,R83"},
+		{"R98,U47,R26,D63,R33,U87,L62,D20,R33,U53,R51", "U98,R91,D20,R16,D67,R40,U7,R15,U6,R7"},
+		{"R10,U5", "U2,R3,D2,R4"},
+	}
+
+	for _, pair := range wires {
+		// when
+		expected, err := computeClosestPair(pair[0], pair[1], IntersectionOptions{})
+		distance, delay := computeGeneralClosestIntersection(mustComputeWireCoords(pair[0]),
+			mustComputeWireCoords(pair[1]), IntersectionOptions{})
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, big.NewRat(int64(expected.a), 1), distance)
+		assert.Equal(t, big.NewRat(int64(expected.b), 1), delay)
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+type IntersectionOrder string
+
+const (
+	ByDistance    IntersectionOrder = "distance"
+	ByDelay       IntersectionOrder = "delay"
+	ByFirstSteps  IntersectionOrder = "first"
+	BySecondSteps IntersectionOrder = "second"
+	ByX           IntersectionOrder = "x"
+	ByY           IntersectionOrder = "y"
+)
+
+// Intersection is a point shared by two wires with the steps each wire takes to reach it for the first time.
+type Intersection struct {
+	point                   Point
+	distance                int
+	firstSteps, secondSteps int
+}
+
+func parseIntersectionOrder(name string) (IntersectionOrder, error) {
+	switch order := IntersectionOrder(name); order {
+	case ByDistance, ByDelay, ByFirstSteps, BySecondSteps, ByX, ByY:
+		return order, nil
+	}
+	return "", fmt.Errorf("unknown intersection order %q", name)
+}
+
+// computeIntersections returns every point shared by the two wires, in no particular order. Overlapping stretches
+// contribute each of their points.
+func computeIntersections(firstWireInstructions, secondWireInstructions string,
+	options IntersectionOptions) ([]Intersection, error) {
+	wireCoords, err := computeWiresCoords([]string{firstWireInstructions, secondWireInstructions})
+	if err != nil {
+		return nil, err
+	}
+	return computeCoordsIntersections(wireCoords[0], wireCoords[1], options), nil
+}
+
+func computeCoordsIntersections(firstWireCoords, secondWireCoords []Point, options IntersectionOptions) []Intersection {
+	byPoint := make(map[Point]int)
+	var intersections []Intersection
+	for _, shared := range sweepIntersections(firstWireCoords, secondWireCoords, options) {
+		for _, point := range latticePoints(shared.shared) {
+			if point == (Point{0, 0}) && !options.origin {
+				continue
+			}
+			firstSteps := shared.first.steps + manhattanDistance(shared.first.segment.p1, point)
+			secondSteps := shared.second.steps + manhattanDistance(shared.second.segment.p1, point)
+			idx, found := byPoint[point]
+			if !found {
+				byPoint[point] = len(intersections)
+				intersections = append(intersections,
+					Intersection{point, manhattanDistanceFromZero(point), firstSteps, secondSteps})
+				continue
+			}
+			if firstSteps < intersections[idx].firstSteps {
+				intersections[idx].firstSteps = firstSteps
+			}
+			if secondSteps < intersections[idx].secondSteps {
+				intersections[idx].secondSteps = secondSteps
+			}
+		}
+	}
+	return intersections
+}
+
+// sortIntersections sorts in ascending order of the given value, ties are ordered by x and then by y.
+func sortIntersections(intersections []Intersection, order IntersectionOrder) {
+	value := func(intersection Intersection) int {
+		switch order {
+		case ByDelay:
+			return intersection.delay()
+		case ByFirstSteps:
+			return intersection.firstSteps
+		case BySecondSteps:
+			return intersection.secondSteps
+		case ByX:
+			return intersection.point.x
+		case ByY:
+			return intersection.point.y
+		}
+		return intersection.distance
+	}
+	sort.Slice(intersections, func(i, j int) bool {
+		first, second := intersections[i], intersections[j]
+		if value(first) != value(second) {
+			return value(first) < value(second)
+		}
+		if first.point.x != second.point.x {
+			return first.point.x < second.point.x
+		}
+		return first.point.y < second.point.y
+	})
+}
+
+// delay is the combined number of steps both wires take to reach the intersection.
+func (intersection Intersection) delay() int {
+	return intersection.firstSteps + intersection.secondSteps
+}
+
+func (intersection Intersection) String() string {
+	return fmt.Sprintf("(%d,%d) distance %d, steps %d + %d = %d", intersection.point.x, intersection.point.y,
+		intersection.distance, intersection.firstSteps, intersection.secondSteps, intersection.delay())
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestShouldComputeEveryIntersectionWithSteps(t *testing.T) {
+	// given
+	firstWire := "R8,U5,L5,D3"
+	secondWire := "U7,R6,D4,L4"
+
+	// when
+	result, err := computeIntersections(firstWire, secondWire, IntersectionOptions{})
+
+	// then
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []Intersection{{Point{3, 3}, 6, 20, 20}, {Point{6, 5}, 11, 15, 15}}, result)
+}
+
+func TestShouldComputeIntersectionStepsOfFirstVisit(t *testing.T) {
+	// given
+	firstWire := "R5,U2,L2,D4"
+	secondWire := "U1,R3,D3"
+
+	// when
+	result, err := computeIntersections(firstWire, secondWire, IntersectionOptions{})
+	sortIntersections(result, ByY)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []Intersection{
+		{Point{3, -2}, 5, 13, 7},
+		{Point{3, -1}, 4, 12, 6},
+		{Point{3, 0}, 3, 3, 5},
+		{Point{3, 1}, 4, 10, 4},
+	}, result)
+}
+
+func TestShouldAgreeWithClosestIntersectionDistanceAndPathLength(t *testing.T) {
+	// given
+	random := rand.New(rand.NewSource(5))
+
+	for i := 0; i < 20; i++ {
+		firstWire := randomWireInstructions(random, 100, 6)
+		secondWire := randomWireInstructions(random, 100, 6)
+
+		// when
+		intersections, err := computeIntersections(firstWire, secondWire, IntersectionOptions{})
+
+		// then
+		assert.NoError(t, err)
+		closest := Pair{MaxInt, MaxInt}
+		for _, intersection := range intersections {
+			if intersection.distance < closest.a {
+				closest.a = intersection.distance
+			}
+			if intersection.delay() < closest.b {
+				closest.b = intersection.delay()
+			}
+		}
+		expected, _ := computeClosestIntersectionDistanceAndPathLength(firstWire, secondWire)
+		assert.Equal(t, expected, closest)
+	}
+}
+
+func TestShouldSortIntersectionsByAnyValue(t *testing.T) {
+	// given
+	intersections := []Intersection{
+		{Point{3, -2}, 5, 15, 7},
+		{Point{-4, 0}, 4, 9, 6},
+		{Point{3, 1}, 4, 10, 4},
+	}
+	expected := map[IntersectionOrder][]Point{
+		ByDistance:    {{-4, 0}, {3, 1}, {3, -2}},
+		ByDelay:       {{3, 1}, {-4, 0}, {3, -2}},
+		ByFirstSteps:  {{-4, 0}, {3, 1}, {3, -2}},
+		BySecondSteps: {{3, 1}, {-4, 0}, {3, -2}},
+		ByX:           {{-4, 0}, {3, -2}, {3, 1}},
+		ByY:           {{3, -2}, {-4, 0}, {3, 1}},
+	}
+
+	for order, points := range expected {
+		// when
+		sortIntersections(intersections, order)
+
+		// then
+		result := make([]Point, len(intersections))
+		for i, intersection := range intersections {
+			result[i] = intersection.point
+		}
+		assert.Equal(t, points, result, order)
+	}
+}
+
+func TestShouldParseIntersectionOrder(t *testing.T) {
+	// when
+	order, err := parseIntersectionOrder("delay")
+	_, unknownErr := parseIntersectionOrder("length")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, ByDelay, order)
+	assert.EqualError(t, unknownErr, "unknown intersection order \"length\"")
+}
+
+func TestShouldFormatIntersection(t *testing.T) {
+	// given
+	intersection := Intersection{Point{3, 3}, 6, 20, 20}
+
+	// when
+	result := intersection.String()
+
+	// then
+	assert.Equal(t, "(3,3) distance 6, steps 20 + 20 = 40", result)
+}
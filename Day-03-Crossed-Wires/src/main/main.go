@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -13,6 +14,9 @@ const path = "/src/data/input"
 const MaxUint = ^uint(0)
 const MaxInt = int(MaxUint >> 1)
 
+// ErrNotAxisAligned is returned by the grid based code for a wire with a segment that is not horizontal or vertical.
+var ErrNotAxisAligned = errors.New("only horizontal and vertical segments are supported here")
+
 type Point struct {
 	x, y int
 }
@@ -30,50 +34,123 @@ type IntersectionConfiguration struct {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 	fmt.Println("--- Day 3: Crossed Wires ---")
 	pwd, _ := os.Getwd()
-	input, err := getInput(pwd + path)
+	wireInstructions, err := loadWireInstructions(pwd + path)
 	if err != nil {
 		log.Fatal(err)
 	}
-	wireInstructions := strings.Split(input, "\n")
-	if len(wireInstructions) != 2 {
-		log.Fatal(fmt.Sprintf("Incorrect input wire instructions length (expected 2, found %v",
-			len(wireInstructions)))
+	if len(wireInstructions) > 2 {
+		pairs, err := computeWirePairs(wireInstructions, IntersectionOptions{})
+		if err != nil {
+			log.Fatal(gridOnlyError("solving more than two wires", err))
+		}
+		for _, pair := range pairs {
+			fmt.Println(pair)
+		}
+		return
+	}
+	firstWireCoords, err := parseWireCoords(wireInstructions[0])
+	if err != nil {
+		log.Fatal(fmt.Errorf("wire 1: %v", err))
+	}
+	secondWireCoords, err := parseWireCoords(wireInstructions[1])
+	if err != nil {
+		log.Fatal(fmt.Errorf("wire 2: %v", err))
+	}
+	if !isAxisAlignedWire(firstWireCoords) || !isAxisAlignedWire(secondWireCoords) {
+		distance, delay := computeGeneralClosestIntersection(firstWireCoords, secondWireCoords, IntersectionOptions{})
+		if distance == nil {
+			log.Fatal("the wires do not intersect")
+		}
+		fmt.Println(fmt.Sprintf("Part 1 >> %s", distance.RatString()))
+		fmt.Println(fmt.Sprintf("Part 2 >> %s", delay.RatString()))
+		return
+	}
+	closestDistance, err := computeClosestIntersectionDistanceAndPathLength(wireInstructions[0], wireInstructions[1])
+	if err != nil {
+		log.Fatal(err)
 	}
-	closestDistance := computeClosestIntersectionDistanceAndPathLength(wireInstructions[0], wireInstructions[1])
 	fmt.Println(fmt.Sprintf("Part 1 >> %d", closestDistance.a))
 	fmt.Println(fmt.Sprintf("Part 2 >> %d", closestDistance.b))
 }
 
-func computeClosestIntersectionDistanceAndPathLength(firstWireInstructions string, secondWireInstructions string) Pair {
-	firstWireCoords := computeWireCoords(firstWireInstructions)
-	secondWireCoords := computeWireCoords(secondWireInstructions)
-	minDistance := MaxInt
-	minPathLength := MaxInt
+// computeClosestIntersectionDistanceAndPathLength returns the distance to the intersection closest to the central
+// port and the lowest sum of steps both wires take to reach an intersection. Collinear overlaps count at every point.
+func computeClosestIntersectionDistanceAndPathLength(firstWireInstructions string,
+	secondWireInstructions string) (Pair, error) {
+	return computeClosestPair(firstWireInstructions, secondWireInstructions, IntersectionOptions{})
+}
+
+// computeClosestPair is computeClosestIntersectionDistanceAndPathLength with options. Both values are MaxInt when the
+// wires do not intersect.
+func computeClosestPair(firstWireInstructions, secondWireInstructions string, options IntersectionOptions) (Pair,
+	error) {
+	wireCoords, err := computeWiresCoords([]string{firstWireInstructions, secondWireInstructions})
+	if err != nil {
+		return Pair{}, err
+	}
+	return computeCoordsClosestPair(wireCoords[0], wireCoords[1], options), nil
+}
+
+func computeCoordsClosestPair(firstWireCoords, secondWireCoords []Point, options IntersectionOptions) Pair {
+	closest, minPathLength, found := computeClosestIntersection(firstWireCoords, secondWireCoords, ManhattanMetric{},
+		Point{0, 0}, options)
+	if !found {
+		return Pair{MaxInt, MaxInt}
+	}
+	return Pair{closest.intersection.distance, minPathLength}
+}
+
+// computeClosestIntersection finds the intersection nearest to the reference point in the metric, ties going to the
+// lowest x and then y like in rankIntersections, and the lowest sum of steps of any intersection. It reports false
+// when the wires do not intersect.
+func computeClosestIntersection(firstWireCoords, secondWireCoords []Point, metric Metric, reference Point,
+	options IntersectionOptions) (closest RankedIntersection, minPathLength int, found bool) {
+	minPathLength = MaxInt
 	firstPath := 0
 	for i := 0; i < len(firstWireCoords)-1; i++ {
 		segment1 := Segment{firstWireCoords[i], firstWireCoords[i+1]}
-		firstPath += manhattanDistance(segment1.p1, segment1.p2)
-		secondPath := firstPath
+		secondPath := 0
 		for j := 0; j < len(secondWireCoords)-1; j++ {
 			segment2 := Segment{secondWireCoords[j], secondWireCoords[j+1]}
-			secondPath += manhattanDistance(segment2.p1, segment2.p2)
-			if intersectionPoint := computeSegmentsIntersectionPoint(segment1, segment2); intersectionPoint != nil {
-				manhattanDistanceFromZero := manhattanDistanceFromZero(*intersectionPoint)
-				secondPath -= manhattanDistance(segment1.p2, *intersectionPoint)
-				secondPath -= manhattanDistance(segment2.p2, *intersectionPoint)
-				if manhattanDistanceFromZero < minDistance {
-					minDistance = manhattanDistanceFromZero
+			for _, point := range computeSegmentsMeetingPoints(segment1, segment2, metric, reference, options) {
+				if point == (Point{0, 0}) && !options.origin {
+					continue
+				}
+				firstSteps := firstPath + manhattanDistance(segment1.p1, point)
+				secondSteps := secondPath + manhattanDistance(segment2.p1, point)
+				if firstSteps+secondSteps < minPathLength {
+					minPathLength = firstSteps + secondSteps
+				}
+				candidate := RankedIntersection{
+					Intersection{point, manhattanDistanceFromZero(point), firstSteps, secondSteps},
+					metric.distance(reference, point),
 				}
-				if secondPath < minPathLength {
-					minPathLength = secondPath
+				switch {
+				case !found || isRankedBefore(candidate, closest):
+					closest, found = candidate, true
+				case point == closest.intersection.point:
+					// like computeCoordsIntersections, keep the fewest steps of every wire reaching the point
+					if firstSteps < closest.intersection.firstSteps {
+						closest.intersection.firstSteps = firstSteps
+					}
+					if secondSteps < closest.intersection.secondSteps {
+						closest.intersection.secondSteps = secondSteps
+					}
 				}
-				break
 			}
+			secondPath += manhattanDistance(segment2.p1, segment2.p2)
 		}
+		firstPath += manhattanDistance(segment1.p1, segment1.p2)
 	}
-	return Pair{minDistance, minPathLength}
+	return closest, minPathLength, found
 }
 
 func getInput(path string) (string, error) {
@@ -84,31 +161,69 @@ func getInput(path string) (string, error) {
 	return string(content), nil
 }
 
-func computeWireCoords(instructions string) []Point {
+// computeWireCoords returns the corners of a wire for the grid based code, which only handles horizontal and vertical
+// segments.
+func computeWireCoords(instructions string) ([]Point, error) {
+	points, err := parseWireCoords(instructions)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(points)-1; i++ {
+		if !isAxisAligned(Segment{points[i], points[i+1]}) {
+			return nil, fmt.Errorf("instruction %d: %w", i+1, ErrNotAxisAligned)
+		}
+	}
+	return points, nil
+}
+
+// parseWireCoords understands R/L/U/D, the diagonals NE/NW/SE/SW, which move by the value along both axes, and
+// vectors such as V3,-2, whose y value is the next comma separated field.
+func parseWireCoords(instructions string) ([]Point, error) {
 	points := []Point{{0, 0}}
 	instructionsTable := strings.Split(instructions, ",")
-	for idx, instruction := range instructionsTable {
-		value, err := strconv.Atoi(string(instruction[1:]))
+	for idx := 0; idx < len(instructionsTable); idx++ {
+		// a vector takes two fields, so instructions are counted by the points they add
+		number := len(points)
+		instruction := strings.TrimSpace(instructionsTable[idx])
+		direction := strings.TrimRight(instruction, "-0123456789")
+		value, err := strconv.Atoi(instruction[len(direction):])
 		if err != nil {
-			log.Fatal(err)
-		}
-		var oldPoint Point
-		if idx > 0 {
-			oldPoint = points[idx]
+			return nil, fmt.Errorf("instruction %d: invalid instruction %q", number, instruction)
 		}
-		switch string(instruction[0]) {
+		point := points[len(points)-1]
+		switch direction {
 		case "R":
-			oldPoint.x += value
+			point.x += value
 		case "L":
-			oldPoint.x -= value
+			point.x -= value
 		case "U":
-			oldPoint.y += value
+			point.y += value
 		case "D":
-			oldPoint.y -= value
+			point.y -= value
+		case "NE":
+			point.x, point.y = point.x+value, point.y+value
+		case "NW":
+			point.x, point.y = point.x-value, point.y+value
+		case "SE":
+			point.x, point.y = point.x+value, point.y-value
+		case "SW":
+			point.x, point.y = point.x-value, point.y-value
+		case "V":
+			if idx+1 == len(instructionsTable) {
+				return nil, fmt.Errorf("instruction %d: vector %q misses its y value", number, instruction)
+			}
+			idx++
+			y, err := strconv.Atoi(strings.TrimSpace(instructionsTable[idx]))
+			if err != nil {
+				return nil, fmt.Errorf("instruction %d: invalid vector y value %q", number, instructionsTable[idx])
+			}
+			point.x, point.y = point.x+value, point.y+y
+		default:
+			return nil, fmt.Errorf("instruction %d: unknown direction %q", number, direction)
 		}
-		points = append(points, oldPoint)
+		points = append(points, point)
 	}
-	return points
+	return points, nil
 }
 
 func computeSegmentsIntersectionPoint(segment1, segment2 Segment) *Point {
@@ -191,7 +306,7 @@ func manhattanDistanceFromZero(point Point) int {
 }
 
 func manhattanDistance(point1, point2 Point) int {
-	x := point1.x- point2.x
+	x := point1.x - point2.x
 	y := point1.y - point2.y
 	if x < 0 {
 		x *= -1
@@ -113,10 +113,12 @@ func TestShouldComputeWireCoordinates(t *testing.T) {
 	instructionsTwo := "U7,R6,D4,L4"
 
 	// when
-	coordsOne := computeWireCoords(instructionsOne)
-	coordsTwo := computeWireCoords(instructionsTwo)
+	coordsOne, errOne := computeWireCoords(instructionsOne)
+	coordsTwo, errTwo := computeWireCoords(instructionsTwo)
 
 	// then
+	assert.Nil(t, errOne)
+	assert.Nil(t, errTwo)
 	assert.NotNil(t, coordsOne)
 	assert.NotNil(t, coordsTwo)
 	assert.Equal(t, []Point{{0, 0}, {5, 0}, {5, 5}}, coordsOne)
@@ -214,12 +216,16 @@ func TestShouldComputeClosestIntersectionPointBasedOnInstruction(t *testing.T) {
 	secondWireInstruction4 := "R8,U5,L5,D3"
 
 	// when
-	result1 := computeClosestIntersectionDistanceAndPathLength(firstWireInstruction1, secondWireInstruction1)
-	result2 := computeClosestIntersectionDistanceAndPathLength(firstWireInstruction2, secondWireInstruction2)
-	result3 := computeClosestIntersectionDistanceAndPathLength(firstWireInstruction3, secondWireInstruction3)
-	result4 := computeClosestIntersectionDistanceAndPathLength(firstWireInstruction4, secondWireInstruction4)
+	result1, err1 := computeClosestIntersectionDistanceAndPathLength(firstWireInstruction1, secondWireInstruction1)
+	result2, err2 := computeClosestIntersectionDistanceAndPathLength(firstWireInstruction2, secondWireInstruction2)
+	result3, err3 := computeClosestIntersectionDistanceAndPathLength(firstWireInstruction3, secondWireInstruction3)
+	result4, err4 := computeClosestIntersectionDistanceAndPathLength(firstWireInstruction4, secondWireInstruction4)
 
 	// then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Nil(t, err4)
 	assert.Equal(t, Pair{159, 610}, result1)
 	assert.Equal(t, Pair{135, 410}, result2)
 	assert.Equal(t, Pair{15, 60}, result3)
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Metric measures how far apart two points of the grid are. Distances are float64, so they are only exact up to 2^53.
+type Metric interface {
+	distance(point1, point2 Point) float64
+	// length measures a vector between points that are not necessarily grid points.
+	length(dx, dy float64) float64
+}
+
+type ManhattanMetric struct{}
+
+type ChebyshevMetric struct{}
+
+type EuclideanMetric struct{}
+
+// SquaredEuclideanMetric orders points like EuclideanMetric without taking square roots.
+type SquaredEuclideanMetric struct{}
+
+// RankedIntersection is an intersection with its distance from the reference point in the chosen metric.
+type RankedIntersection struct {
+	intersection Intersection
+	distance     float64
+}
+
+func parseMetric(name string) (Metric, error) {
+	switch strings.ToLower(name) {
+	case "manhattan":
+		return ManhattanMetric{}, nil
+	case "chebyshev":
+		return ChebyshevMetric{}, nil
+	case "euclidean":
+		return EuclideanMetric{}, nil
+	case "squared-euclidean":
+		return SquaredEuclideanMetric{}, nil
+	}
+	return nil, fmt.Errorf("unknown metric %q", name)
+}
+
+// parsePoint reads a point written as "x,y".
+func parsePoint(text string) (Point, error) {
+	fields := strings.Split(text, ",")
+	if len(fields) != 2 {
+		return Point{}, fmt.Errorf("expected a point as x,y, found %q", text)
+	}
+	x, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return Point{}, fmt.Errorf("expected a point as x,y, found %q", text)
+	}
+	y, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+	if err != nil {
+		return Point{}, fmt.Errorf("expected a point as x,y, found %q", text)
+	}
+	return Point{x, y}, nil
+}
+
+func (ManhattanMetric) distance(point1, point2 Point) float64 {
+	return float64(manhattanDistance(point1, point2))
+}
+
+func (ChebyshevMetric) distance(point1, point2 Point) float64 {
+	return math.Max(math.Abs(float64(point1.x-point2.x)), math.Abs(float64(point1.y-point2.y)))
+}
+
+func (EuclideanMetric) distance(point1, point2 Point) float64 {
+	return math.Sqrt(SquaredEuclideanMetric{}.distance(point1, point2))
+}
+
+func (SquaredEuclideanMetric) distance(point1, point2 Point) float64 {
+	dx, dy := float64(point1.x-point2.x), float64(point1.y-point2.y)
+	return dx*dx + dy*dy
+}
+
+func (ManhattanMetric) length(dx, dy float64) float64 {
+	return math.Abs(dx) + math.Abs(dy)
+}
+
+func (ChebyshevMetric) length(dx, dy float64) float64 {
+	return math.Max(math.Abs(dx), math.Abs(dy))
+}
+
+func (EuclideanMetric) length(dx, dy float64) float64 {
+	return math.Hypot(dx, dy)
+}
+
+func (SquaredEuclideanMetric) length(dx, dy float64) float64 {
+	return dx*dx + dy*dy
+}
+
+// rankIntersections returns the intersections from the closest to the reference point, ties are ordered by x and
+// then by y.
+func rankIntersections(intersections []Intersection, metric Metric, reference Point) []RankedIntersection {
+	ranked := make([]RankedIntersection, len(intersections))
+	for i, intersection := range intersections {
+		ranked[i] = RankedIntersection{intersection, metric.distance(reference, intersection.point)}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		return isRankedBefore(ranked[i], ranked[j])
+	})
+	return ranked
+}
+
+func isRankedBefore(first, second RankedIntersection) bool {
+	if first.distance != second.distance {
+		return first.distance < second.distance
+	}
+	if first.intersection.point.x != second.intersection.point.x {
+		return first.intersection.point.x < second.intersection.point.x
+	}
+	return first.intersection.point.y < second.intersection.point.y
+}
+
+// RankedRationalIntersection is RankedIntersection for wires running in any direction.
+type RankedRationalIntersection struct {
+	intersection RationalIntersection
+	distance     float64
+}
+
+// rankRationalIntersections is rankIntersections for intersections between grid points. The offsets from the
+// reference are exact until they are converted to float64.
+func rankRationalIntersections(intersections []RationalIntersection, metric Metric,
+	reference Point) []RankedRationalIntersection {
+	ranked := make([]RankedRationalIntersection, len(intersections))
+	for i, intersection := range intersections {
+		dx, _ := new(big.Rat).Sub(intersection.point.x, big.NewRat(int64(reference.x), 1)).Float64()
+		dy, _ := new(big.Rat).Sub(intersection.point.y, big.NewRat(int64(reference.y), 1)).Float64()
+		ranked[i] = RankedRationalIntersection{intersection, metric.length(dx, dy)}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].distance != ranked[j].distance {
+			return ranked[i].distance < ranked[j].distance
+		}
+		return ranked[i].intersection.point.isBefore(ranked[j].intersection.point)
+	})
+	return ranked
+}
+
+func (ranked RankedRationalIntersection) String() string {
+	return fmt.Sprintf("%v distance %s, delay %s", ranked.intersection.point,
+		strconv.FormatFloat(ranked.distance, 'f', -1, 64), ranked.intersection.delay().RatString())
+}
+
+func (ranked RankedIntersection) String() string {
+	return fmt.Sprintf("(%d,%d) distance %s, delay %d", ranked.intersection.point.x, ranked.intersection.point.y,
+		strconv.FormatFloat(ranked.distance, 'f', -1, 64), ranked.intersection.delay())
+}
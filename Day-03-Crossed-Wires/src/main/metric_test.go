@@ -0,0 +1,175 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestShouldMeasureDistanceInEveryMetric(t *testing.T) {
+	// given
+	point1 := Point{1, 5}
+	point2 := Point{-2, 1}
+
+	// when
+	manhattan := ManhattanMetric{}.distance(point1, point2)
+	chebyshev := ChebyshevMetric{}.distance(point1, point2)
+	euclidean := EuclideanMetric{}.distance(point1, point2)
+	squaredEuclidean := SquaredEuclideanMetric{}.distance(point1, point2)
+
+	// then
+	assert.Equal(t, 7.0, manhattan)
+	assert.Equal(t, 4.0, chebyshev)
+	assert.Equal(t, 5.0, euclidean)
+	assert.Equal(t, 25.0, squaredEuclidean)
+}
+
+func TestShouldParseMetricNames(t *testing.T) {
+	// when
+	manhattan, manhattanErr := parseMetric("manhattan")
+	squared, squaredErr := parseMetric("Squared-Euclidean")
+	_, unknownErr := parseMetric("taxicab")
+
+	// then
+	assert.NoError(t, manhattanErr)
+	assert.NoError(t, squaredErr)
+	assert.Equal(t, ManhattanMetric{}, manhattan)
+	assert.Equal(t, SquaredEuclideanMetric{}, squared)
+	assert.EqualError(t, unknownErr, "unknown metric \"taxicab\"")
+}
+
+func TestShouldParsePoint(t *testing.T) {
+	// when
+	point, err := parsePoint("-3, 12")
+	_, wrongErr := parsePoint("3")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, Point{-3, 12}, point)
+	assert.EqualError(t, wrongErr, "expected a point as x,y, found \"3\"")
+}
+
+func TestShouldRankIntersectionsDifferentlyInEveryMetric(t *testing.T) {
+	// given
+	intersections := []Intersection{{Point{3, 3}, 6, 0, 0}, {Point{5, 0}, 5, 0, 0}, {Point{0, -4}, 4, 0, 0}}
+	expected := []struct {
+		metric Metric
+		points []Point
+	}{
+		{ManhattanMetric{}, []Point{{0, -4}, {5, 0}, {3, 3}}},
+		{ChebyshevMetric{}, []Point{{3, 3}, {0, -4}, {5, 0}}},
+		{EuclideanMetric{}, []Point{{0, -4}, {3, 3}, {5, 0}}},
+		{SquaredEuclideanMetric{}, []Point{{0, -4}, {3, 3}, {5, 0}}},
+	}
+
+	for _, test := range expected {
+		// when
+		ranked := rankIntersections(intersections, test.metric, Point{0, 0})
+
+		// then
+		result := make([]Point, len(ranked))
+		for i, intersection := range ranked {
+			result[i] = intersection.intersection.point
+		}
+		assert.Equal(t, test.points, result)
+	}
+}
+
+func TestShouldFindClosestIntersectionToReferencePoint(t *testing.T) {
+	// given
+	firstWireCoords := mustComputeWireCoords("R8,U5,L5,D3")
+	secondWireCoords := mustComputeWireCoords("U7,R6,D4,L4")
+
+	// when
+	fromOrigin, _, found := computeClosestIntersection(firstWireCoords, secondWireCoords, EuclideanMetric{}, Point{0, 0},
+		IntersectionOptions{})
+	fromReference, _, _ := computeClosestIntersection(firstWireCoords, secondWireCoords, ManhattanMetric{}, Point{7, 5},
+		IntersectionOptions{})
+
+	// then
+	assert.True(t, found)
+	assert.Equal(t, Point{3, 3}, fromOrigin.intersection.point)
+	assert.InDelta(t, math.Sqrt(18), fromOrigin.distance, 1e-9)
+	assert.Equal(t, RankedIntersection{Intersection{Point{6, 5}, 11, 15, 15}, 1}, fromReference)
+	assert.Equal(t, "(6,5) distance 1, delay 30", fromReference.String())
+}
+
+func TestShouldRankIntersectionsBetweenGridPoints(t *testing.T) {
+	// given
+	firstWireCoords, _ := parseWireCoords("NE4")
+	secondWireCoords, _ := parseWireCoords("U3,SE3,U5,L2")
+	intersections := computeGeneralIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// when
+	fromOrigin := rankRationalIntersections(intersections, EuclideanMetric{}, Point{0, 0})
+	fromReference := rankRationalIntersections(intersections, ChebyshevMetric{}, Point{5, 5})
+
+	// then
+	assert.Equal(t, 2, len(fromOrigin))
+	assert.InDelta(t, math.Sqrt(4.5), fromOrigin[0].distance, 1e-9)
+	assert.Equal(t, "(3,3) distance 2, delay 18", fromReference[0].String())
+	assert.Equal(t, "(3/2,3/2) distance 3.5, delay 9", fromReference[1].String())
+}
+
+func TestShouldMatchPartOneWithManhattanMetricFromCentralPort(t *testing.T) {
+	// given
+	firstWireCoords := mustComputeWireCoords("R98,U47,R26,D63,R33,U87,L62,D20,R33,U53,R51")
+	secondWireCoords := mustComputeWireCoords("U98,R91,D20,R16,D67,R40,U7,R15,U6,R7")
+
+	// when
+	result, _, found := computeClosestIntersection(firstWireCoords, secondWireCoords, ManhattanMetric{}, Point{0, 0},
+		IntersectionOptions{})
+
+	// then
+	assert.True(t, found)
+	assert.Equal(t, 135.0, result.distance)
+}
+
+func TestShouldReportNoClosestIntersectionOfSeparateWires(t *testing.T) {
+	// when
+	_, _, found := computeClosestIntersection(mustComputeWireCoords("R5"), mustComputeWireCoords("L5"),
+		ChebyshevMetric{}, Point{0, 0}, IntersectionOptions{})
+
+	// then
+	assert.False(t, found)
+}
+
+func TestShouldBreakTiesAlongOverlapByLowestPoint(t *testing.T) {
+	// given
+	firstWireCoords := mustComputeWireCoords("R10")
+	secondWireCoords := mustComputeWireCoords("U1,R1,D1,R8")
+
+	// when
+	closest, delay, found := computeClosestIntersection(firstWireCoords, secondWireCoords, ChebyshevMetric{}, Point{5, 4},
+		IntersectionOptions{})
+
+	// then
+	assert.True(t, found)
+	assert.Equal(t, RankedIntersection{Intersection{Point{1, 0}, 1, 1, 3}, 4}, closest)
+	assert.Equal(t, 4, delay)
+}
+
+func TestShouldFindSameClosestIntersectionAsRanking(t *testing.T) {
+	// given
+	random := rand.New(rand.NewSource(7))
+	metrics := []Metric{ManhattanMetric{}, ChebyshevMetric{}, EuclideanMetric{}, SquaredEuclideanMetric{}}
+
+	for i := 0; i < 200; i++ {
+		firstWireCoords := mustComputeWireCoords(randomWireInstructions(random, 12, 8))
+		secondWireCoords := mustComputeWireCoords(randomWireInstructions(random, 12, 8))
+		metric := metrics[random.Intn(len(metrics))]
+		reference := Point{random.Intn(21) - 10, random.Intn(21) - 10}
+		options := IntersectionOptions{touching: random.Intn(2) == 0, origin: random.Intn(2) == 0}
+		intersections := computeCoordsIntersections(firstWireCoords, secondWireCoords, options)
+
+		// when
+		closest, _, found := computeClosestIntersection(firstWireCoords, secondWireCoords, metric, reference, options)
+
+		// then
+		assert.Equal(t, len(intersections) > 0, found)
+		if len(intersections) > 0 {
+			assert.Equal(t, rankIntersections(intersections, metric, reference)[0], closest)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import "sort"
+
+// computeSegmentsOverlap returns the part shared by two collinear segments, from its lower to its higher end, or nil
+// when the segments are not collinear or share less than a unit of length.
+func computeSegmentsOverlap(segment1, segment2 Segment) *Segment {
+	return computeCollinearOverlap(segment1, segment2, 1)
+}
+
+func computeCollinearOverlap(segment1, segment2 Segment, minimumLength int) *Segment {
+	if segment1.p1 == segment1.p2 || segment2.p1 == segment2.p2 || isHorizontal(segment1) != isHorizontal(segment2) {
+		return nil
+	}
+	if isHorizontal(segment1) {
+		if segment1.p1.y != segment2.p1.y {
+			return nil
+		}
+		from, to := overlapRange(segment1.p1.x, segment1.p2.x, segment2.p1.x, segment2.p2.x)
+		if to-from < minimumLength {
+			return nil
+		}
+		return &Segment{Point{from, segment1.p1.y}, Point{to, segment1.p1.y}}
+	}
+	if segment1.p1.x != segment2.p1.x {
+		return nil
+	}
+	from, to := overlapRange(segment1.p1.y, segment1.p2.y, segment2.p1.y, segment2.p2.y)
+	if to-from < minimumLength {
+		return nil
+	}
+	return &Segment{Point{segment1.p1.x, from}, Point{segment1.p1.x, to}}
+}
+
+// computeSegmentsMeetingPoints returns the single point shared by two segments. For a collinear overlap it returns
+// the lattice points that may be closest to the reference point or have the lowest delay: the steps of both wires
+// change linearly along the overlap, so the delay is lowest at one of its ends, and closestLatticePoint finds the
+// point nearest to the reference. Unless the central port counts, its neighbours stand in for it, as it may be an
+// end or the nearest point.
+func computeSegmentsMeetingPoints(segment1, segment2 Segment, metric Metric, reference Point,
+	options IntersectionOptions) []Point {
+	overlap := computeSegmentsSharedPart(segment1, segment2, options)
+	if overlap == nil {
+		return nil
+	}
+	if overlap.p1 == overlap.p2 {
+		return []Point{overlap.p1}
+	}
+	closest := closestLatticePoint(*overlap, metric, reference)
+	points := []Point{overlap.p1, overlap.p2, closest}
+	if !options.origin && isPointOnSegment(Point{0, 0}, *overlap) {
+		for _, neighbour := range []Point{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			if isPointOnSegment(neighbour, *overlap) {
+				points = append(points, neighbour)
+			}
+		}
+	}
+	return points
+}
+
+// closestLatticePoint returns the lowest of the points of an axis-aligned segment nearest to the reference. Moving
+// from the lower end towards the projection of the reference never takes a point further away in any metric, so the
+// nearest points start where bisection first finds the distance of the projection.
+func closestLatticePoint(segment Segment, metric Metric, reference Point) Point {
+	low, high := segment.p1, segment.p2
+	if high.x < low.x || high.y < low.y {
+		low, high = high, low
+	}
+	projection := Point{clamp(reference.x, low.x, high.x), clamp(reference.y, low.y, high.y)}
+	lowest := metric.distance(reference, projection)
+	dx, dy := sign(projection.x-low.x), sign(projection.y-low.y)
+	first := sort.Search(manhattanDistance(low, projection), func(step int) bool {
+		return metric.distance(reference, Point{low.x + step*dx, low.y + step*dy}) <= lowest
+	})
+	return Point{low.x + first*dx, low.y + first*dy}
+}
+
+// latticePoints returns every point of an axis-aligned segment, from p1 to p2.
+func latticePoints(segment Segment) []Point {
+	length := manhattanDistance(segment.p1, segment.p2)
+	points := make([]Point, 0, length+1)
+	dx, dy := sign(segment.p2.x-segment.p1.x), sign(segment.p2.y-segment.p1.y)
+	for step := 0; step <= length; step++ {
+		points = append(points, Point{segment.p1.x + step*dx, segment.p1.y + step*dy})
+	}
+	return points
+}
+
+func isPointOnSegment(point Point, segment Segment) bool {
+	xMin, xMax := orderedRange(segment.p1.x, segment.p2.x)
+	yMin, yMax := orderedRange(segment.p1.y, segment.p2.y)
+	return xMin <= point.x && point.x <= xMax && yMin <= point.y && point.y <= yMax
+}
+
+// overlapRange returns the common part of the ranges [a1, a2] and [b1, b2], given in any order. It is empty when
+// from > to.
+func overlapRange(a1, a2, b1, b2 int) (from, to int) {
+	aMin, aMax := orderedRange(a1, a2)
+	bMin, bMax := orderedRange(b1, b2)
+	from, to = aMin, aMax
+	if bMin > from {
+		from = bMin
+	}
+	if bMax < to {
+		to = bMax
+	}
+	return from, to
+}
+
+func orderedRange(a, b int) (int, int) {
+	if a <= b {
+		return a, b
+	}
+	return b, a
+}
+
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+func sign(value int) int {
+	switch {
+	case value > 0:
+		return 1
+	case value < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldComputeCollinearSegmentsOverlap(t *testing.T) {
+	// given
+	horizontal1 := Segment{Point{0, 5}, Point{10, 5}}
+	horizontal2 := Segment{Point{12, 5}, Point{4, 5}}
+	vertical1 := Segment{Point{-3, 7}, Point{-3, -2}}
+	vertical2 := Segment{Point{-3, 1}, Point{-3, 3}}
+
+	// when
+	result1 := computeSegmentsOverlap(horizontal1, horizontal2)
+	result2 := computeSegmentsOverlap(vertical1, vertical2)
+
+	// then
+	assert.Equal(t, &Segment{Point{4, 5}, Point{10, 5}}, result1)
+	assert.Equal(t, &Segment{Point{-3, 1}, Point{-3, 3}}, result2)
+}
+
+func TestShouldNotComputeOverlapOfSegmentsNotSharingALine(t *testing.T) {
+	// given
+	segment := Segment{Point{0, 5}, Point{10, 5}}
+	parallel := Segment{Point{0, 6}, Point{10, 6}}
+	touching := Segment{Point{10, 5}, Point{15, 5}}
+	perpendicular := Segment{Point{5, 0}, Point{5, 10}}
+
+	// when
+	result1 := computeSegmentsOverlap(segment, parallel)
+	result2 := computeSegmentsOverlap(segment, touching)
+	result3 := computeSegmentsOverlap(segment, perpendicular)
+
+	// then
+	assert.Nil(t, result1)
+	assert.Nil(t, result2)
+	assert.Nil(t, result3)
+}
+
+func TestShouldComputeLatticePointsOfSegment(t *testing.T) {
+	// given
+	segment := Segment{Point{2, 3}, Point{2, 0}}
+
+	// when
+	result := latticePoints(segment)
+
+	// then
+	assert.Equal(t, []Point{{2, 3}, {2, 2}, {2, 1}, {2, 0}}, result)
+}
+
+func TestShouldComputeClosestIntersectionOfOverlappingWires(t *testing.T) {
+	// given
+	firstWire := "R10,U5"
+	secondWire := "U2,R3,D2,R4"
+
+	// when
+	result, err := computeClosestIntersectionDistanceAndPathLength(firstWire, secondWire)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, Pair{3, 10}, result)
+}
+
+func TestShouldSkipCentralPortInsideOverlap(t *testing.T) {
+	// given
+	firstWire := "L5,R10"
+	secondWire := "R3"
+
+	// when
+	result, err := computeClosestIntersectionDistanceAndPathLength(firstWire, secondWire)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, Pair{1, 12}, result)
+}
+
+func TestShouldListEveryPointOfOverlap(t *testing.T) {
+	// given
+	firstWireCoords := mustComputeWireCoords("U7,R6,D4,L4")
+	secondWireCoords := mustComputeWireCoords("U3,R5")
+
+	// when
+	result := computeWiresIntersectionPoints(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// then
+	assert.ElementsMatch(t, []Point{{0, 1}, {0, 2}, {0, 3}, {2, 3}, {3, 3}, {4, 3}, {5, 3}}, result)
+}
+
+func TestShouldFindPointCrossedByThreeWiresThroughOverlap(t *testing.T) {
+	// given
+	wireInstructions := []string{"R8,U5,L5,D3", "U7,R6,D4,L4", "U3,R5"}
+
+	// when
+	result, err := computePointsCrossedByAtLeast(wireInstructions, 3, IntersectionOptions{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []CrossingPoint{{Point{3, 3}, []int{1, 2, 3}}}, result)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SelfCrossing is a grid point a wire visits more than once, with the steps taken at every visit.
+type SelfCrossing struct {
+	point  Point
+	visits []int
+}
+
+// Loop is the part of a wire between two consecutive visits of the same point.
+type Loop struct {
+	point         Point
+	start, length int
+}
+
+// SelfIntersections describes how a wire crosses itself. shortcutLength is the length of the wire with every loop
+// cut out: whenever it comes back to a point, the way since the earlier visit is dropped.
+type SelfIntersections struct {
+	crossings              []SelfCrossing
+	loops                  []Loop
+	length, shortcutLength int
+}
+
+// computeSelfIntersections walks the wire one step at a time, counting steps like the delay of part 2, and records
+// every point it comes back to. Crossings are ordered by their first visit, loops by the step closing them.
+func computeSelfIntersections(wireCoords []Point) SelfIntersections {
+	result := SelfIntersections{}
+	visits := map[Point][]int{{0, 0}: {0}}
+	var revisited []Point
+	shortcut := []Point{{0, 0}}
+	shortcutIndex := map[Point]int{{0, 0}: 0}
+	steps := 0
+	for i := 0; i < len(wireCoords)-1; i++ {
+		segment := Segment{wireCoords[i], wireCoords[i+1]}
+		for _, point := range latticePoints(segment)[1:] {
+			steps++
+			previous := visits[point]
+			if len(previous) == 1 {
+				revisited = append(revisited, point)
+			}
+			if len(previous) > 0 {
+				start := previous[len(previous)-1]
+				result.loops = append(result.loops, Loop{point, start, steps - start})
+			}
+			visits[point] = append(previous, steps)
+			if idx, found := shortcutIndex[point]; found {
+				for _, dropped := range shortcut[idx+1:] {
+					delete(shortcutIndex, dropped)
+				}
+				shortcut = shortcut[:idx+1]
+				continue
+			}
+			shortcutIndex[point] = len(shortcut)
+			shortcut = append(shortcut, point)
+		}
+	}
+	for _, point := range revisited {
+		result.crossings = append(result.crossings, SelfCrossing{point, visits[point]})
+	}
+	sort.Slice(result.crossings, func(i, j int) bool {
+		return result.crossings[i].visits[0] < result.crossings[j].visits[0]
+	})
+	result.length = steps
+	result.shortcutLength = len(shortcut) - 1
+	return result
+}
+
+func (loop Loop) String() string {
+	return fmt.Sprintf("loop at (%d,%d) from step %d, length %d", loop.point.x, loop.point.y, loop.start, loop.length)
+}
+
+func (self SelfIntersections) String() string {
+	return fmt.Sprintf("length %d, shortcut %d, %d self-crossings, %d loops", self.length, self.shortcutLength,
+		len(self.crossings), len(self.loops))
+}
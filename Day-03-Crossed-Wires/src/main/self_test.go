@@ -0,0 +1,73 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShouldFindSingleSelfCrossing(t *testing.T) {
+	// given
+	wireCoords := mustComputeWireCoords("R5,U2,L2,D4")
+
+	// when
+	result := computeSelfIntersections(wireCoords)
+
+	// then
+	assert.Equal(t, []SelfCrossing{{Point{3, 0}, []int{3, 11}}}, result.crossings)
+	assert.Equal(t, []Loop{{Point{3, 0}, 3, 8}}, result.loops)
+	assert.Equal(t, 13, result.length)
+	assert.Equal(t, 5, result.shortcutLength)
+}
+
+func TestShouldFindLoopThroughCentralPort(t *testing.T) {
+	// given
+	wireCoords := mustComputeWireCoords("U2,R2,D2,L2,D1")
+
+	// when
+	result := computeSelfIntersections(wireCoords)
+
+	// then
+	assert.Equal(t, []SelfCrossing{{Point{0, 0}, []int{0, 8}}}, result.crossings)
+	assert.Equal(t, []Loop{{Point{0, 0}, 0, 8}}, result.loops)
+	assert.Equal(t, 1, result.shortcutLength)
+}
+
+func TestShouldCutNestedLoopsOnce(t *testing.T) {
+	// given
+	wireCoords := mustComputeWireCoords("R4,U2,L1,D3,L1,U4,R4")
+
+	// when
+	result := computeSelfIntersections(wireCoords)
+
+	// then
+	assert.Equal(t, []SelfCrossing{{Point{2, 0}, []int{2, 12}}, {Point{3, 0}, []int{3, 9}}}, result.crossings)
+	assert.Equal(t, []Loop{{Point{3, 0}, 3, 6}, {Point{2, 0}, 2, 10}}, result.loops)
+	assert.Equal(t, 19, result.length)
+	assert.Equal(t, 9, result.shortcutLength)
+	assert.Equal(t, "length 19, shortcut 9, 2 self-crossings, 2 loops", result.String())
+}
+
+func TestShouldFindNoSelfCrossingInExampleWire(t *testing.T) {
+	// given
+	wireCoords := mustComputeWireCoords("R8,U5,L5,D3")
+
+	// when
+	result := computeSelfIntersections(wireCoords)
+
+	// then
+	assert.Empty(t, result.crossings)
+	assert.Empty(t, result.loops)
+	assert.Equal(t, 21, result.length)
+	assert.Equal(t, 21, result.shortcutLength)
+}
+
+func TestShouldFormatLoop(t *testing.T) {
+	// given
+	loop := Loop{Point{3, 0}, 3, 8}
+
+	// when
+	result := loop.String()
+
+	// then
+	assert.Equal(t, "loop at (3,0) from step 3, length 8", result)
+}
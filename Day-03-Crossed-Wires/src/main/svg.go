@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// svgMinimumExtent keeps markers and labels of tiny drawings from covering the wires.
+const svgMinimumExtent = 20
+
+var wireColours = []string{"#d62728", "#1f77b4", "#2ca02c", "#ff7f0e", "#9467bd", "#8c564b", "#e377c2", "#17becf"}
+
+// Bounds is the smallest rectangle holding every point of the wires and the central port.
+type Bounds struct {
+	minX, maxX, minY, maxY int
+}
+
+func computeBounds(wireCoords [][]Point) Bounds {
+	bounds := Bounds{}
+	for _, coords := range wireCoords {
+		for _, point := range coords {
+			if point.x < bounds.minX {
+				bounds.minX = point.x
+			}
+			if point.x > bounds.maxX {
+				bounds.maxX = point.x
+			}
+			if point.y < bounds.minY {
+				bounds.minY = point.y
+			}
+			if point.y > bounds.maxY {
+				bounds.maxY = point.y
+			}
+		}
+	}
+	return bounds
+}
+
+// renderSVG draws every wire in its own colour with the central port and the intersections of every pair of wires.
+// The intersection closest to the port and the one with the lowest delay are highlighted. The y axis points up, like
+// in the puzzle, and the viewBox fits the drawing whatever the size of the wires.
+func renderSVG(wireCoords [][]Point) string {
+	bounds := computeBounds(wireCoords)
+	extent := bounds.maxX - bounds.minX
+	if height := bounds.maxY - bounds.minY; height > extent {
+		extent = height
+	}
+	if extent < svgMinimumExtent {
+		extent = svgMinimumExtent
+	}
+	unit := float64(extent) / 200
+	margin := float64(extent)/20 + 4*unit
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"%s %s %s %s\">\n",
+		svgNumber(float64(bounds.minX)-margin), svgNumber(float64(-bounds.maxY)-margin),
+		svgNumber(float64(bounds.maxX-bounds.minX)+2*margin), svgNumber(float64(bounds.maxY-bounds.minY)+2*margin)))
+	for i, coords := range wireCoords {
+		points := make([]string, len(coords))
+		for j, point := range coords {
+			points[j] = fmt.Sprintf("%d,%d", point.x, -point.y)
+		}
+		builder.WriteString(fmt.Sprintf("  <polyline class=\"wire\" points=\"%s\" fill=\"none\" stroke=\"%s\" "+
+			"stroke-width=\"%s\"><title>wire %d</title></polyline>\n", strings.Join(points, " "),
+			wireColours[i%len(wireColours)], svgNumber(unit), i+1))
+	}
+	var intersections []Intersection
+	for i := 0; i < len(wireCoords); i++ {
+		for j := i + 1; j < len(wireCoords); j++ {
+			intersections = append(intersections, computeCoordsIntersections(wireCoords[i], wireCoords[j],
+				IntersectionOptions{})...)
+		}
+	}
+	sortIntersections(intersections, ByDistance)
+	closest, lowestDelay := -1, -1
+	for i, intersection := range intersections {
+		if closest < 0 {
+			closest = i
+		}
+		if lowestDelay < 0 || intersection.delay() < intersections[lowestDelay].delay() {
+			lowestDelay = i
+		}
+	}
+	for i, intersection := range intersections {
+		class, radius, fill := "intersection", 2*unit, "none"
+		switch i {
+		case closest:
+			class, radius, fill = "intersection closest", 4*unit, "#ffd700"
+		case lowestDelay:
+			class, radius, fill = "intersection lowest-delay", 4*unit, "#ff00ff"
+		}
+		if i == closest && i == lowestDelay {
+			class = "intersection closest lowest-delay"
+		}
+		writeSVGMarker(&builder, class, intersection.point, radius, fill, unit,
+			fmt.Sprintf("distance %d, delay %d", intersection.distance, intersection.delay()))
+	}
+	writeSVGMarker(&builder, "origin", Point{0, 0}, 3*unit, "#000000", unit, "central port")
+	builder.WriteString("</svg>\n")
+	return builder.String()
+}
+
+// writeSVGMarker draws a circle with a coordinate label next to it and a tooltip.
+func writeSVGMarker(builder *strings.Builder, class string, point Point, radius float64, fill string, unit float64,
+	title string) {
+	builder.WriteString(fmt.Sprintf("  <g class=\"%s\">\n", class))
+	builder.WriteString(fmt.Sprintf("    <circle cx=\"%d\" cy=\"%d\" r=\"%s\" fill=\"%s\" stroke=\"#000000\" "+
+		"stroke-width=\"%s\"><title>%s</title></circle>\n", point.x, -point.y, svgNumber(radius), fill, svgNumber(unit),
+		title))
+	builder.WriteString(fmt.Sprintf("    <text x=\"%s\" y=\"%s\" font-size=\"%s\">(%d,%d)</text>\n",
+		svgNumber(float64(point.x)+radius+unit), svgNumber(float64(-point.y)-radius-unit), svgNumber(6*unit), point.x,
+		point.y))
+	builder.WriteString("  </g>\n")
+}
+
+// svgNumber rounds to two decimal places, which is plenty for integer coordinates and keeps the output stable.
+func svgNumber(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestShouldComputeBoundsIncludingCentralPort(t *testing.T) {
+	// given
+	wireCoords := [][]Point{mustComputeWireCoords("R8,U5"), mustComputeWireCoords("U7,R6")}
+
+	// when
+	result := computeBounds(wireCoords)
+
+	// then
+	assert.Equal(t, Bounds{0, 8, 0, 7}, result)
+}
+
+func TestShouldRenderWiresToSVG(t *testing.T) {
+	// given
+	wireCoords := [][]Point{mustComputeWireCoords("R8,U5,L5,D3"), mustComputeWireCoords("U7,R6,D4,L4")}
+
+	// when
+	result := renderSVG(wireCoords)
+
+	// then
+	assert.True(t, strings.HasPrefix(result, "<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"-1.4 -8.4 10.8 9.8\">"))
+	assert.True(t, strings.HasSuffix(result, "</svg>\n"))
+	assert.Contains(t, result, "points=\"0,0 8,0 8,-5 3,-5 3,-2\" fill=\"none\" stroke=\"#d62728\"")
+	assert.Contains(t, result, "points=\"0,0 0,-7 6,-7 6,-3 2,-3\" fill=\"none\" stroke=\"#1f77b4\"")
+	assert.Contains(t, result, "<g class=\"origin\">")
+	assert.Contains(t, result, "<text x=\"0.4\" y=\"-0.4\" font-size=\"0.6\">(0,0)</text>")
+}
+
+func TestShouldHighlightClosestAndLowestDelayIntersections(t *testing.T) {
+	// given
+	wireCoords := [][]Point{mustComputeWireCoords("R8,U5,L5,D3"), mustComputeWireCoords("U7,R6,D4,L4")}
+
+	// when
+	result := renderSVG(wireCoords)
+
+	// then
+	assert.Contains(t, result, "<g class=\"intersection closest\">\n"+
+		"    <circle cx=\"3\" cy=\"-3\" r=\"0.4\" fill=\"#ffd700\" stroke=\"#000000\" stroke-width=\"0.1\">"+
+		"<title>distance 6, delay 40</title></circle>\n"+
+		"    <text x=\"3.5\" y=\"-3.5\" font-size=\"0.6\">(3,3)</text>")
+	assert.Contains(t, result, "<g class=\"intersection lowest-delay\">\n"+
+		"    <circle cx=\"6\" cy=\"-5\" r=\"0.4\" fill=\"#ff00ff\"")
+}
+
+func TestShouldMarkEveryIntersectionOfEveryWirePair(t *testing.T) {
+	// given
+	wireCoords := make([][]Point, len(threeWires))
+	for i, instructions := range threeWires {
+		wireCoords[i] = mustComputeWireCoords(instructions)
+	}
+
+	// when
+	result := renderSVG(wireCoords)
+
+	// then
+	assert.Equal(t, 3, strings.Count(result, "<polyline"))
+	assert.Equal(t, 6, strings.Count(result, "<g class=\"intersection"))
+	assert.Equal(t, 1, strings.Count(result, "<g class=\"intersection closest lowest-delay\">"))
+	assert.Contains(t, result, "<title>distance 5, delay 14</title>")
+}
@@ -0,0 +1,276 @@
+package main
+
+import (
+	"math/rand"
+	"sort"
+)
+
+const (
+	removeEvent = iota
+	queryEvent
+	insertEvent
+)
+
+// WireSegment is a segment of a wire, numbered from 0, with the steps the wire takes before the segment starts.
+type WireSegment struct {
+	wire    int
+	index   int
+	segment Segment
+	steps   int
+}
+
+// SegmentIntersection is the part shared by segments of two different wires: a single point where they cross, or
+// the sub-segment where they overlap. first always belongs to the first wire.
+type SegmentIntersection struct {
+	first, second WireSegment
+	shared        Segment
+}
+
+type sweepEvent struct {
+	x, kind int
+	segment WireSegment
+}
+
+// activeKey orders horizontal segments by height, the segment index breaks ties.
+type activeKey struct {
+	y, index int
+}
+
+type activeNode struct {
+	key         activeKey
+	priority    int64
+	segment     WireSegment
+	left, right *activeNode
+}
+
+// ActiveSegments is a treap of the horizontal segments of one wire that the sweep line currently crosses.
+type ActiveSegments struct {
+	root   *activeNode
+	random *rand.Rand
+}
+
+func computeWireSegments(wire int, coords []Point) []WireSegment {
+	segments := make([]WireSegment, 0, len(coords))
+	steps := 0
+	for i := 0; i < len(coords)-1; i++ {
+		segment := Segment{coords[i], coords[i+1]}
+		segments = append(segments, WireSegment{wire, i, segment, steps})
+		steps += manhattanDistance(segment.p1, segment.p2)
+	}
+	return segments
+}
+
+// sweepIntersections returns every crossing and overlap between the two wires in O((n+m) log(n+m) + k). A vertical
+// line sweeps from left to right, keeping the horizontal segments it crosses ordered by height, so every vertical
+// segment only visits the horizontals it crosses. Collinear overlaps are found separately, line by line.
+func sweepIntersections(firstWireCoords, secondWireCoords []Point, options IntersectionOptions) []SegmentIntersection {
+	wires := [2][]WireSegment{computeWireSegments(0, firstWireCoords), computeWireSegments(1, secondWireCoords)}
+	var events []sweepEvent
+	for _, segments := range wires {
+		for _, wireSegment := range segments {
+			segment := wireSegment.segment
+			switch {
+			case segment.p1 == segment.p2:
+			case isHorizontal(segment):
+				xMin, xMax := orderedRange(segment.p1.x, segment.p2.x)
+				events = append(events, sweepEvent{xMin, insertEvent, wireSegment},
+					sweepEvent{xMax, removeEvent, wireSegment})
+			default:
+				events = append(events, sweepEvent{segment.p1.x, queryEvent, wireSegment})
+			}
+		}
+	}
+	// at the same x removals go first and insertions last, so segments only touching at their ends do not cross,
+	// unless touching counts, when the order is reversed
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].x != events[j].x {
+			return events[i].x < events[j].x
+		}
+		if options.touching {
+			return events[i].kind > events[j].kind
+		}
+		return events[i].kind < events[j].kind
+	})
+	random := rand.New(rand.NewSource(1))
+	active := [2]*ActiveSegments{{random: random}, {random: random}}
+	var intersections []SegmentIntersection
+	for _, event := range events {
+		wire, segment := event.segment.wire, event.segment.segment
+		switch event.kind {
+		case insertEvent:
+			active[wire].insert(event.segment)
+		case removeEvent:
+			active[wire].remove(event.segment)
+		case queryEvent:
+			yMin, yMax := orderedRange(segment.p1.y, segment.p2.y)
+			active[1-wire].visitRange(yMin, yMax, options.touching, func(horizontal WireSegment) {
+				point := Point{event.x, horizontal.segment.p1.y}
+				intersections = append(intersections, newSegmentIntersection(event.segment, horizontal,
+					Segment{point, point}))
+			})
+		}
+	}
+	return append(intersections, computeOverlaps(wires[0], wires[1], options)...)
+}
+
+// computeOverlaps groups the segments of both wires by the line they lie on and sorts every group by the lower end,
+// so each segment is only compared with the segments of the other wire that reach past its start.
+func computeOverlaps(firstWire, secondWire []WireSegment, options IntersectionOptions) []SegmentIntersection {
+	type line struct {
+		horizontal bool
+		position   int
+	}
+	lines := make(map[line][]WireSegment)
+	var order []line
+	for _, wireSegment := range append(append([]WireSegment{}, firstWire...), secondWire...) {
+		segment := wireSegment.segment
+		if segment.p1 == segment.p2 {
+			continue
+		}
+		key := line{true, segment.p1.y}
+		if !isHorizontal(segment) {
+			key = line{false, segment.p1.x}
+		}
+		if lines[key] == nil {
+			order = append(order, key)
+		}
+		lines[key] = append(lines[key], wireSegment)
+	}
+	var intersections []SegmentIntersection
+	for _, key := range order {
+		segments := lines[key]
+		if len(segments) < 2 {
+			continue
+		}
+		sort.SliceStable(segments, func(i, j int) bool {
+			return lowerEnd(segments[i].segment) < lowerEnd(segments[j].segment)
+		})
+		var open [2][]WireSegment
+		for _, wireSegment := range segments {
+			other := 1 - wireSegment.wire
+			start := lowerEnd(wireSegment.segment)
+			reaching := open[other][:0]
+			for _, candidate := range open[other] {
+				if upperEnd(candidate.segment) > start || options.touching && upperEnd(candidate.segment) == start {
+					reaching = append(reaching, candidate)
+					overlap := computeSegmentsSharedPart(wireSegment.segment, candidate.segment, options)
+					intersections = append(intersections, newSegmentIntersection(wireSegment, candidate, *overlap))
+				}
+			}
+			open[other] = reaching
+			open[wireSegment.wire] = append(open[wireSegment.wire], wireSegment)
+		}
+	}
+	return intersections
+}
+
+func newSegmentIntersection(segment1, segment2 WireSegment, shared Segment) SegmentIntersection {
+	if segment1.wire == 0 {
+		return SegmentIntersection{segment1, segment2, shared}
+	}
+	return SegmentIntersection{segment2, segment1, shared}
+}
+
+// lowerEnd and upperEnd return the ends of an axis-aligned segment along the axis it runs on.
+func lowerEnd(segment Segment) int {
+	if isHorizontal(segment) {
+		low, _ := orderedRange(segment.p1.x, segment.p2.x)
+		return low
+	}
+	low, _ := orderedRange(segment.p1.y, segment.p2.y)
+	return low
+}
+
+func upperEnd(segment Segment) int {
+	if isHorizontal(segment) {
+		_, high := orderedRange(segment.p1.x, segment.p2.x)
+		return high
+	}
+	_, high := orderedRange(segment.p1.y, segment.p2.y)
+	return high
+}
+
+func (active *ActiveSegments) insert(segment WireSegment) {
+	node := &activeNode{key: activeKeyOf(segment), priority: active.random.Int63(), segment: segment}
+	left, right := splitActive(active.root, node.key)
+	active.root = mergeActive(mergeActive(left, node), right)
+}
+
+func (active *ActiveSegments) remove(segment WireSegment) {
+	active.root = removeActive(active.root, activeKeyOf(segment))
+}
+
+// visitRange calls visit for every active segment strictly between the heights yMin and yMax, from the lowest. With
+// inclusive, segments at yMin and yMax are visited too.
+func (active *ActiveSegments) visitRange(yMin, yMax int, inclusive bool, visit func(WireSegment)) {
+	var walk func(node *activeNode)
+	walk = func(node *activeNode) {
+		if node == nil {
+			return
+		}
+		if node.key.y > yMin || inclusive && node.key.y == yMin {
+			walk(node.left)
+		}
+		if yMin < node.key.y && node.key.y < yMax || inclusive && (node.key.y == yMin || node.key.y == yMax) {
+			visit(node.segment)
+		}
+		if node.key.y < yMax || inclusive && node.key.y == yMax {
+			walk(node.right)
+		}
+	}
+	walk(active.root)
+}
+
+func activeKeyOf(segment WireSegment) activeKey {
+	return activeKey{segment.segment.p1.y, segment.index}
+}
+
+func (key activeKey) less(other activeKey) bool {
+	return key.y < other.y || key.y == other.y && key.index < other.index
+}
+
+// splitActive splits a treap into the nodes with keys lower than key and the remaining ones.
+func splitActive(node *activeNode, key activeKey) (*activeNode, *activeNode) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.key.less(key) {
+		left, right := splitActive(node.right, key)
+		node.right = left
+		return node, right
+	}
+	left, right := splitActive(node.left, key)
+	node.left = right
+	return left, node
+}
+
+// mergeActive joins two treaps, all keys of left being lower than the keys of right.
+func mergeActive(left, right *activeNode) *activeNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.priority > right.priority {
+		left.right = mergeActive(left.right, right)
+		return left
+	}
+	right.left = mergeActive(left, right.left)
+	return right
+}
+
+func removeActive(node *activeNode, key activeKey) *activeNode {
+	if node == nil {
+		return nil
+	}
+	switch {
+	case node.key == key:
+		return mergeActive(node.left, node.right)
+	case key.less(node.key):
+		node.left = removeActive(node.left, key)
+	default:
+		node.right = removeActive(node.right, key)
+	}
+	return node
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestShouldSweepCrossingsOfExampleWires(t *testing.T) {
+	// given
+	firstWireCoords := mustComputeWireCoords("R8,U5,L5,D3")
+	secondWireCoords := mustComputeWireCoords("U7,R6,D4,L4")
+
+	// when
+	result := sweepIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// then
+	assert.ElementsMatch(t, []SegmentIntersection{
+		{
+			WireSegment{0, 3, Segment{Point{3, 5}, Point{3, 2}}, 18},
+			WireSegment{1, 3, Segment{Point{6, 3}, Point{2, 3}}, 17},
+			Segment{Point{3, 3}, Point{3, 3}},
+		},
+		{
+			WireSegment{0, 2, Segment{Point{8, 5}, Point{3, 5}}, 13},
+			WireSegment{1, 2, Segment{Point{6, 7}, Point{6, 3}}, 13},
+			Segment{Point{6, 5}, Point{6, 5}},
+		},
+	}, result)
+}
+
+func TestShouldSweepOverlapsOfCollinearWires(t *testing.T) {
+	// given
+	firstWireCoords := mustComputeWireCoords("R10,U5")
+	secondWireCoords := mustComputeWireCoords("U2,R3,D2,R4")
+
+	// when
+	result := sweepIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// then
+	assert.Equal(t, []SegmentIntersection{{
+		WireSegment{0, 0, Segment{Point{0, 0}, Point{10, 0}}, 0},
+		WireSegment{1, 3, Segment{Point{3, 0}, Point{7, 0}}, 7},
+		Segment{Point{3, 0}, Point{7, 0}},
+	}}, result)
+}
+
+func TestShouldSweepSameIntersectionsAsNestedLoop(t *testing.T) {
+	// given
+	random := rand.New(rand.NewSource(3))
+	found := 0
+
+	for i := 0; i < 20; i++ {
+		firstWireCoords := mustComputeWireCoords(randomWireInstructions(random, 200, 5))
+		secondWireCoords := mustComputeWireCoords(randomWireInstructions(random, 200, 5))
+
+		// when
+		result := sweepIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+		// then
+		expected := computeIntersectionsWithNestedLoop(firstWireCoords, secondWireCoords, IntersectionOptions{})
+		assert.ElementsMatch(t, expected, result)
+		found += len(expected)
+	}
+	assert.Greater(t, found, 0)
+}
+
+func TestShouldKeepActiveSegmentsOrderedByHeight(t *testing.T) {
+	// given
+	active := &ActiveSegments{random: rand.New(rand.NewSource(1))}
+	for i, y := range []int{5, -3, 8, 0, 5, 12} {
+		active.insert(WireSegment{index: i, segment: Segment{Point{0, y}, Point{1, y}}})
+	}
+	active.remove(WireSegment{index: 2, segment: Segment{Point{0, 8}, Point{1, 8}}})
+
+	// when
+	var visited []int
+	active.visitRange(-3, 12, false, func(segment WireSegment) {
+		visited = append(visited, segment.index)
+	})
+
+	// then
+	assert.Equal(t, []int{3, 0, 4}, visited)
+}
+
+// computeIntersectionsWithNestedLoop is the O(n·m) reference the sweep is checked against.
+func computeIntersectionsWithNestedLoop(firstWireCoords, secondWireCoords []Point,
+	options IntersectionOptions) []SegmentIntersection {
+	var intersections []SegmentIntersection
+	for _, first := range computeWireSegments(0, firstWireCoords) {
+		for _, second := range computeWireSegments(1, secondWireCoords) {
+			if shared := computeSegmentsSharedPart(first.segment, second.segment, options); shared != nil {
+				intersections = append(intersections, SegmentIntersection{first, second, *shared})
+			}
+		}
+	}
+	return intersections
+}
+
+// randomWireInstructions returns a wire turning at every step, with lengths from 1 to maxLength.
+func randomWireInstructions(random *rand.Rand, segments, maxLength int) string {
+	instructions := make([]string, segments)
+	for i := range instructions {
+		directions := "RL"
+		if i%2 == 1 {
+			directions = "UD"
+		}
+		instructions[i] = fmt.Sprintf("%c%d", directions[random.Intn(2)], 1+random.Intn(maxLength))
+	}
+	return strings.Join(instructions, ",")
+}
+
+// mustComputeWireCoords is computeWireCoords for wires the test knows to be valid.
+func mustComputeWireCoords(instructions string) []Point {
+	coords, err := computeWireCoords(instructions)
+	if err != nil {
+		panic(err)
+	}
+	return coords
+}
+
+func benchmarkWires(segments int) ([]Point, []Point) {
+	random := rand.New(rand.NewSource(1))
+	return mustComputeWireCoords(randomWireInstructions(random, segments, 1000)),
+		mustComputeWireCoords(randomWireInstructions(random, segments, 1000))
+}
+
+func BenchmarkNestedLoopIntersections1k(b *testing.B) {
+	firstWireCoords, secondWireCoords := benchmarkWires(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		computeIntersectionsWithNestedLoop(firstWireCoords, secondWireCoords, IntersectionOptions{})
+	}
+}
+
+func BenchmarkSweepIntersections1k(b *testing.B) {
+	firstWireCoords, secondWireCoords := benchmarkWires(1000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sweepIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+	}
+}
+
+func BenchmarkSweepIntersections100k(b *testing.B) {
+	firstWireCoords, secondWireCoords := benchmarkWires(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sweepIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+	}
+}
+
+func BenchmarkSweepIntersections200k(b *testing.B) {
+	firstWireCoords, secondWireCoords := benchmarkWires(200000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sweepIntersections(firstWireCoords, secondWireCoords, IntersectionOptions{})
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+// IntersectionOptions widen what counts as an intersection. By default wires must cross or overlap, so T-junctions,
+// corners and segments meeting end to end do not count, and neither does the central port.
+type IntersectionOptions struct {
+	touching bool
+	origin   bool
+}
+
+// computeSegmentsSharedPart returns the part shared by two segments: a single point where perpendicular segments
+// cross, or the sub-segment where collinear segments overlap. With touching, perpendicular segments also meet when
+// one ends on the other, and collinear segments when they only share an end, which gives a single point.
+func computeSegmentsSharedPart(segment1, segment2 Segment, options IntersectionOptions) *Segment {
+	if segment1.p1 == segment1.p2 || segment2.p1 == segment2.p2 {
+		return nil
+	}
+	if areSegmentsPerpendicular(segment1, segment2) {
+		interConfig := getSegmentsIntersectionConfiguration(segment1, segment2)
+		if areSegmentsIntersectsBasedOnConfig(interConfig) ||
+			options.touching && areSegmentsTouchingBasedOnConfig(interConfig) {
+			point := Point{interConfig.constVer, interConfig.constHor}
+			return &Segment{point, point}
+		}
+		return nil
+	}
+	minimumLength := 1
+	if options.touching {
+		minimumLength = 0
+	}
+	return computeCollinearOverlap(segment1, segment2, minimumLength)
+}
+
+// areSegmentsTouchingBasedOnConfig is the inclusive counterpart of areSegmentsIntersectsBasedOnConfig, it also
+// accepts segments meeting at the end of either of them.
+func areSegmentsTouchingBasedOnConfig(configuration IntersectionConfiguration) bool {
+	return configuration.horizontalMin <= configuration.constVer &&
+		configuration.horizontalMax >= configuration.constVer &&
+		configuration.verticalMin <= configuration.constHor &&
+		configuration.verticalMax >= configuration.constHor
+}
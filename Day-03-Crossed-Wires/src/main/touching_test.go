@@ -0,0 +1,111 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+var touchingIntersections = IntersectionOptions{touching: true}
+
+func TestShouldCheckIfSegmentsTouchBasedOnConfig(t *testing.T) {
+	// given
+	crossing := IntersectionConfiguration{1, 1, -5, 3, -8, 12}
+	tJunction := IntersectionConfiguration{2, 3, 1, 3, 1, 2}
+	apart := IntersectionConfiguration{3, 3, 1, 3, 1, 2}
+
+	// when
+	result1 := areSegmentsTouchingBasedOnConfig(crossing)
+	result2 := areSegmentsTouchingBasedOnConfig(tJunction)
+	result3 := areSegmentsTouchingBasedOnConfig(apart)
+
+	// then
+	assert.True(t, result1)
+	assert.True(t, result2)
+	assert.False(t, result3)
+}
+
+func TestShouldComputeSharedPartOnlyForTouchingSegmentsWhenAsked(t *testing.T) {
+	// given
+	horizontal := Segment{Point{0, 5}, Point{10, 5}}
+	tJunction := Segment{Point{4, 5}, Point{4, 9}}
+	endToEnd := Segment{Point{10, 5}, Point{15, 5}}
+
+	// when
+	strictTJunction := computeSegmentsSharedPart(horizontal, tJunction, IntersectionOptions{})
+	strictEndToEnd := computeSegmentsSharedPart(horizontal, endToEnd, IntersectionOptions{})
+	touchingTJunction := computeSegmentsSharedPart(horizontal, tJunction, touchingIntersections)
+	touchingEndToEnd := computeSegmentsSharedPart(horizontal, endToEnd, touchingIntersections)
+
+	// then
+	assert.Nil(t, strictTJunction)
+	assert.Nil(t, strictEndToEnd)
+	assert.Equal(t, &Segment{Point{4, 5}, Point{4, 5}}, touchingTJunction)
+	assert.Equal(t, &Segment{Point{10, 5}, Point{10, 5}}, touchingEndToEnd)
+}
+
+func TestShouldCountTJunctionOnlyWhenTouchingCounts(t *testing.T) {
+	// given
+	firstWire := "R10"
+	secondWire := "U3,R5,D3"
+
+	// when
+	strict, strictErr := computeClosestPair(firstWire, secondWire, IntersectionOptions{})
+	touching, touchingErr := computeClosestPair(firstWire, secondWire, touchingIntersections)
+
+	// then
+	assert.NoError(t, strictErr)
+	assert.NoError(t, touchingErr)
+	assert.Equal(t, Pair{MaxInt, MaxInt}, strict)
+	assert.Equal(t, Pair{5, 16}, touching)
+}
+
+func TestShouldCountCornersWhereWiresMeet(t *testing.T) {
+	// given
+	firstWire := "R5,U5"
+	secondWire := "U5,R5"
+
+	// when
+	result, err := computeIntersections(firstWire, secondWire, touchingIntersections)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []Intersection{{Point{5, 5}, 10, 10, 10}}, result)
+}
+
+func TestShouldExcludeCentralPortUnlessAsked(t *testing.T) {
+	// given
+	firstWire := "R10"
+	secondWire := "U3,R5,D3"
+	withOrigin := IntersectionOptions{touching: true, origin: true}
+
+	// when
+	withoutOrigin, withoutOriginErr := computeIntersections(firstWire, secondWire, touchingIntersections)
+	withOriginResult, withOriginErr := computeIntersections(firstWire, secondWire, withOrigin)
+	closest, closestErr := computeClosestPair(firstWire, secondWire, withOrigin)
+
+	// then
+	assert.NoError(t, withoutOriginErr)
+	assert.NoError(t, withOriginErr)
+	assert.NoError(t, closestErr)
+	assert.Equal(t, []Intersection{{Point{5, 0}, 5, 5, 11}}, withoutOrigin)
+	assert.ElementsMatch(t, []Intersection{{Point{0, 0}, 0, 0, 0}, {Point{5, 0}, 5, 5, 11}}, withOriginResult)
+	assert.Equal(t, Pair{0, 0}, closest)
+}
+
+func TestShouldSweepSameTouchingIntersectionsAsNestedLoop(t *testing.T) {
+	// given
+	random := rand.New(rand.NewSource(7))
+
+	for i := 0; i < 20; i++ {
+		firstWireCoords := mustComputeWireCoords(randomWireInstructions(random, 100, 4))
+		secondWireCoords := mustComputeWireCoords(randomWireInstructions(random, 100, 4))
+
+		// when
+		result := sweepIntersections(firstWireCoords, secondWireCoords, touchingIntersections)
+
+		// then
+		expected := computeIntersectionsWithNestedLoop(firstWireCoords, secondWireCoords, touchingIntersections)
+		assert.ElementsMatch(t, expected, result)
+	}
+}
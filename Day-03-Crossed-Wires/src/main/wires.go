@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WirePair holds the answers of both puzzle parts for two wires, numbered from 1 in input order. Both values of
+// closest are MaxInt when the wires never cross.
+type WirePair struct {
+	first, second int
+	closest       Pair
+}
+
+// CrossingPoint is a point where at least two different wires cross, with the numbers of all wires crossing there.
+type CrossingPoint struct {
+	point Point
+	wires []int
+}
+
+// parseWireInstructions returns one instruction string per non-blank line of the input.
+func parseWireInstructions(input string) ([]string, error) {
+	wireInstructions := splitWireInstructions(input)
+	if len(wireInstructions) < 2 {
+		return nil, fmt.Errorf("expected at least 2 wires, found %d", len(wireInstructions))
+	}
+	return wireInstructions, nil
+}
+
+func splitWireInstructions(input string) []string {
+	var wireInstructions []string
+	for _, line := range strings.Split(input, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			wireInstructions = append(wireInstructions, line)
+		}
+	}
+	return wireInstructions
+}
+
+// computeWiresCoords computes the coordinates of every wire, numbering the wires from 1 in errors.
+func computeWiresCoords(wireInstructions []string) ([][]Point, error) {
+	wireCoords := make([][]Point, len(wireInstructions))
+	for i, instructions := range wireInstructions {
+		coords, err := computeWireCoords(instructions)
+		if err != nil {
+			return nil, fmt.Errorf("wire %d: %w", i+1, err)
+		}
+		wireCoords[i] = coords
+	}
+	return wireCoords, nil
+}
+
+// parseWiresCoords is computeWiresCoords for wires running in any direction.
+func parseWiresCoords(wireInstructions []string) ([][]Point, error) {
+	wireCoords := make([][]Point, len(wireInstructions))
+	for i, instructions := range wireInstructions {
+		coords, err := parseWireCoords(instructions)
+		if err != nil {
+			return nil, fmt.Errorf("wire %d: %w", i+1, err)
+		}
+		wireCoords[i] = coords
+	}
+	return wireCoords, nil
+}
+
+func computeWirePairs(wireInstructions []string, options IntersectionOptions) ([]WirePair, error) {
+	wireCoords, err := computeWiresCoords(wireInstructions)
+	if err != nil {
+		return nil, err
+	}
+	var pairs []WirePair
+	for i := 0; i < len(wireCoords); i++ {
+		for j := i + 1; j < len(wireCoords); j++ {
+			closest := computeCoordsClosestPair(wireCoords[i], wireCoords[j], options)
+			pairs = append(pairs, WirePair{i + 1, j + 1, closest})
+		}
+	}
+	return pairs, nil
+}
+
+// computeWiresIntersectionPoints returns every point where the two wires cross or overlap, each point once.
+func computeWiresIntersectionPoints(firstWireCoords, secondWireCoords []Point, options IntersectionOptions) []Point {
+	var points []Point
+	found := map[Point]bool{{0, 0}: !options.origin}
+	add := func(point Point) {
+		if !found[point] {
+			found[point] = true
+			points = append(points, point)
+		}
+	}
+	for _, intersection := range sweepIntersections(firstWireCoords, secondWireCoords, options) {
+		for _, point := range latticePoints(intersection.shared) {
+			add(point)
+		}
+	}
+	return points
+}
+
+// computePointsCrossedByAtLeast returns the points crossed by at least k different wires, closest to the central
+// port first.
+func computePointsCrossedByAtLeast(wireInstructions []string, k int, options IntersectionOptions) ([]CrossingPoint,
+	error) {
+	if k < 2 {
+		return nil, fmt.Errorf("a crossing needs at least 2 wires, found %d", k)
+	}
+	wireCoords, err := computeWiresCoords(wireInstructions)
+	if err != nil {
+		return nil, err
+	}
+	wiresByPoint := make(map[Point]map[int]bool)
+	for i := 0; i < len(wireCoords); i++ {
+		for j := i + 1; j < len(wireCoords); j++ {
+			for _, point := range computeWiresIntersectionPoints(wireCoords[i], wireCoords[j], options) {
+				if wiresByPoint[point] == nil {
+					wiresByPoint[point] = make(map[int]bool)
+				}
+				wiresByPoint[point][i+1] = true
+				wiresByPoint[point][j+1] = true
+			}
+		}
+	}
+	var crossings []CrossingPoint
+	for point, wireSet := range wiresByPoint {
+		if len(wireSet) < k {
+			continue
+		}
+		wires := make([]int, 0, len(wireSet))
+		for wire := range wireSet {
+			wires = append(wires, wire)
+		}
+		sort.Ints(wires)
+		crossings = append(crossings, CrossingPoint{point, wires})
+	}
+	sort.Slice(crossings, func(i, j int) bool {
+		first, second := crossings[i].point, crossings[j].point
+		if manhattanDistanceFromZero(first) != manhattanDistanceFromZero(second) {
+			return manhattanDistanceFromZero(first) < manhattanDistanceFromZero(second)
+		}
+		if first.x != second.x {
+			return first.x < second.x
+		}
+		return first.y < second.y
+	})
+	return crossings, nil
+}
+
+func (pair WirePair) String() string {
+	if pair.closest.a == MaxInt {
+		return fmt.Sprintf("Wires %d & %d >> no crossing", pair.first, pair.second)
+	}
+	return fmt.Sprintf("Wires %d & %d >> distance %d, delay %d", pair.first, pair.second, pair.closest.a,
+		pair.closest.b)
+}
+
+func (crossing CrossingPoint) String() string {
+	wires := make([]string, len(crossing.wires))
+	for i, wire := range crossing.wires {
+		wires[i] = fmt.Sprint(wire)
+	}
+	return fmt.Sprintf("(%d,%d) distance %d, wires %s", crossing.point.x, crossing.point.y,
+		manhattanDistanceFromZero(crossing.point), strings.Join(wires, ","))
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var threeWires = []string{"R8,U5,L5,D3", "U7,R6,D4,L4", "D2,R5,U10"}
+
+func TestShouldParseAnyNumberOfWires(t *testing.T) {
+	// given
+	input := "R8,U5,L5,D3\nU7,R6,D4,L4\n\nD2,R5,U10\n"
+
+	// when
+	result, err := parseWireInstructions(input)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, threeWires, result)
+}
+
+func TestShouldNotParseSingleWire(t *testing.T) {
+	// given
+	input := "R8,U5,L5,D3\n"
+
+	// when
+	_, err := parseWireInstructions(input)
+
+	// then
+	assert.EqualError(t, err, "expected at least 2 wires, found 1")
+}
+
+func TestShouldComputeEveryWirePair(t *testing.T) {
+	// given
+	wireInstructions := append(threeWires, "L1,D1")
+
+	// when
+	result, err := computeWirePairs(wireInstructions, IntersectionOptions{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []WirePair{
+		{1, 2, Pair{6, 30}},
+		{1, 3, Pair{5, 14}},
+		{1, 4, Pair{MaxInt, MaxInt}},
+		{2, 3, Pair{8, 28}},
+		{2, 4, Pair{MaxInt, MaxInt}},
+		{3, 4, Pair{MaxInt, MaxInt}},
+	}, result)
+	assert.Equal(t, "Wires 1 & 3 >> distance 5, delay 14", result[1].String())
+	assert.Equal(t, "Wires 1 & 4 >> no crossing", result[2].String())
+}
+
+func TestShouldPointGridCommandsToDiagonalOnes(t *testing.T) {
+	// when
+	_, err := computeWirePairs([]string{"R8,U5", "U7,NE2"}, IntersectionOptions{})
+	_, wrongErr := computeWirePairs([]string{"R8,U5", "U7,Q2"}, IntersectionOptions{})
+
+	// then
+	assert.ErrorIs(t, err, ErrNotAxisAligned)
+	assert.EqualError(t, gridOnlyError("svg", err), "wire 2: instruction 2: only horizontal and vertical "+
+		"segments are supported here; svg only handles horizontal and vertical wires, diagonal ones are supported by "+
+		"intersections, closest and the default run of two wires")
+	assert.Equal(t, wrongErr, gridOnlyError("svg", wrongErr))
+}
+
+func TestShouldComputeEveryIntersectionPointOfTwoWires(t *testing.T) {
+	// given
+	firstWireCoords := mustComputeWireCoords("R8,U5,L5,D3")
+	secondWireCoords := mustComputeWireCoords("U7,R6,D4,L4")
+
+	// when
+	result := computeWiresIntersectionPoints(firstWireCoords, secondWireCoords, IntersectionOptions{})
+
+	// then
+	assert.ElementsMatch(t, []Point{{3, 3}, {6, 5}}, result)
+}
+
+func TestShouldComputePointsCrossedByAtLeastTwoWires(t *testing.T) {
+	// when
+	result, err := computePointsCrossedByAtLeast(threeWires, 2, IntersectionOptions{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, []CrossingPoint{
+		{Point{5, 0}, []int{1, 3}},
+		{Point{3, 3}, []int{1, 2}},
+		{Point{5, 3}, []int{2, 3}},
+		{Point{5, 5}, []int{1, 3}},
+		{Point{6, 5}, []int{1, 2}},
+		{Point{5, 7}, []int{2, 3}},
+	}, result)
+	assert.Equal(t, "(5,0) distance 5, wires 1,3", result[0].String())
+}
+
+func TestShouldFindNoPointCrossedByThreeWires(t *testing.T) {
+	// when
+	result, err := computePointsCrossedByAtLeast(threeWires, 3, IntersectionOptions{})
+
+	// then
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestShouldRejectCrossingOfSingleWire(t *testing.T) {
+	// when
+	_, err := computePointsCrossedByAtLeast(threeWires, 1, IntersectionOptions{})
+
+	// then
+	assert.EqualError(t, err, "a crossing needs at least 2 wires, found 1")
+}